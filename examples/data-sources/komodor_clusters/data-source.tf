@@ -0,0 +1,30 @@
+data "komodor_clusters" "production" {
+  name_regex        = "^prod-"
+  connection_status = "connected"
+}
+
+# One availability monitor per connected production cluster
+resource "komodor_monitor" "availability" {
+  for_each = toset(data.komodor_clusters.production.cluster_names)
+
+  name   = "availability-${each.key}"
+  type   = "availability"
+  active = true
+  sensors = jsonencode([{
+    cluster    = each.key
+    namespaces = ["default"]
+  }])
+  sinks = jsonencode({
+    slack = ["availability-alerts"]
+  })
+}
+
+data "komodor_clusters" "disconnected" {
+  connection_status = "disconnected"
+}
+
+output "disconnected_agents" {
+  value = {
+    for c in data.komodor_clusters.disconnected.clusters : c.cluster_name => c.last_heartbeat
+  }
+}
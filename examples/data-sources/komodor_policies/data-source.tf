@@ -0,0 +1,13 @@
+data "komodor_policies" "custom" {
+  kind = "custom"
+}
+
+# Policies that are not attached to any role
+output "unattached_policies" {
+  value = [for p in data.komodor_policies.custom.policies : p.name if length(p.roles) == 0]
+}
+
+# Policies containing a deny statement
+output "policies_with_deny" {
+  value = [for p in data.komodor_policies.custom.policies : p.name if anytrue([for s in p.statements : s.effect == "deny"])]
+}
@@ -0,0 +1,48 @@
+data "komodor_policy_v2" "viewer" {
+  name = "default-viewer-policy"
+}
+
+# Clone a built-in policy and add a statement to it
+resource "komodor_policy_v2" "viewer_plus" {
+  name = "viewer-plus"
+
+  dynamic "statements" {
+    for_each = data.komodor_policy_v2.viewer.statements_list
+    content {
+      actions = statements.value.actions
+      effect  = statements.value.effect
+
+      resources_scope {
+        clusters   = statements.value.resources_scope[0].clusters
+        namespaces = statements.value.resources_scope[0].namespaces
+
+        dynamic "clusters_patterns" {
+          for_each = statements.value.resources_scope[0].clusters_patterns
+          content {
+            include = clusters_patterns.value.include
+            exclude = clusters_patterns.value.exclude
+          }
+        }
+
+        dynamic "namespaces_patterns" {
+          for_each = statements.value.resources_scope[0].namespaces_patterns
+          content {
+            include = namespaces_patterns.value.include
+            exclude = namespaces_patterns.value.exclude
+          }
+        }
+      }
+    }
+  }
+
+  statements {
+    actions = ["manage:monitors"]
+    resources_scope {
+      clusters = ["staging"]
+    }
+  }
+}
+
+output "viewer_roles" {
+  value = [for r in data.komodor_policy_v2.viewer.roles : r.name]
+}
@@ -0,0 +1,29 @@
+data "komodor_roles" "platform" {
+  name_regex = "^platform-"
+  kind       = "custom"
+
+  tags = {
+    owner = "platform-team"
+  }
+}
+
+# Every action granted by each platform role
+output "platform_role_actions" {
+  value = {
+    for role in data.komodor_roles.platform.roles : role.name => distinct(flatten([
+      for policy in role.policies : [for s in policy.statements : s.actions]
+    ]))
+  }
+}
+
+# Fail the plan when a custom role grants access to users management
+check "no_custom_user_admins" {
+  assert {
+    condition = alltrue(flatten([
+      for role in data.komodor_roles.platform.roles : [
+        for policy in role.policies : [for s in policy.statements : !contains(s.actions, "manage:users")]
+      ]
+    ]))
+    error_message = "Custom platform roles must not grant manage:users."
+  }
+}
@@ -0,0 +1,7 @@
+data "komodor_sso_group_role_mapping" "platform" {
+  group_name = "platform-engineers"
+}
+
+output "platform_roles" {
+  value = data.komodor_sso_group_role_mapping.platform.role_names
+}
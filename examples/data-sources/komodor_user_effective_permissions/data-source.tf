@@ -0,0 +1,17 @@
+data "komodor_user_effective_permissions" "alice" {
+  email = "alice@example.com"
+
+  check {
+    action    = "view:all"
+    cluster   = "prod-us"
+    namespace = "payments"
+  }
+}
+
+output "alice_can_view_payments" {
+  value = data.komodor_user_effective_permissions.alice.allowed
+}
+
+output "alice_actions" {
+  value = distinct([for p in data.komodor_user_effective_permissions.alice.permissions : p.action])
+}
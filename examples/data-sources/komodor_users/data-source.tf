@@ -0,0 +1,10 @@
+# Users from example.com that joined this year and are bound to the viewer role
+data "komodor_users" "recent_viewers" {
+  email_domain  = "example.com"
+  role          = "viewer"
+  created_after = "2026-01-01T00:00:00Z"
+}
+
+output "recent_viewer_emails" {
+  value = data.komodor_users.recent_viewers.users[*].email
+}
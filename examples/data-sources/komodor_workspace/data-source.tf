@@ -0,0 +1,13 @@
+# Look up a workspace by ID
+data "komodor_workspace" "by_id" {
+  id = "8a4f2c1e-0b5d-4e8a-9c3f-1d2e3f4a5b6c"
+}
+
+# Or by name. Reading fails unless exactly one workspace has the name.
+data "komodor_workspace" "platform" {
+  name = "platform"
+}
+
+output "platform_scopes" {
+  value = data.komodor_workspace.platform.scopes
+}
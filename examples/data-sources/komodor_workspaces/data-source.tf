@@ -0,0 +1,26 @@
+variable "teams" {
+  type    = list(string)
+  default = ["payments", "checkout", "search"]
+}
+
+data "komodor_workspaces" "teams" {
+  name_regex = "^team-"
+}
+
+locals {
+  workspace_names = [for w in data.komodor_workspaces.teams.workspaces : w.name]
+}
+
+# Fail the plan unless every team has exactly one workspace
+check "one_workspace_per_team" {
+  assert {
+    condition = alltrue([
+      for team in var.teams : length([for n in local.workspace_names : n if n == "team-${team}"]) == 1
+    ])
+    error_message = "Every team must have exactly one workspace named team-<team>."
+  }
+}
+
+output "workspace_authors" {
+  value = { for w in data.komodor_workspaces.teams.workspaces : w.name => w.author_email... }
+}
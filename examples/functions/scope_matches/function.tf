@@ -0,0 +1,27 @@
+# Check whether a statement of a policy grants access to a namespace
+output "payments_in_scope" {
+  value = provider::komodor::scope_matches(
+    komodor_policy_v2.example.statements[0].resources_scope[0],
+    {
+      cluster   = "production"
+      namespace = "payments"
+    }
+  )
+}
+
+# Scopes can also be written inline
+output "legacy_namespace_in_scope" {
+  value = provider::komodor::scope_matches(
+    {
+      clusters = ["production"]
+      namespaces_patterns = [{
+        include = "team-*"
+        exclude = "team-legacy"
+      }]
+    },
+    {
+      cluster   = "production"
+      namespace = "team-legacy"
+    }
+  )
+}
@@ -8,5 +8,6 @@ data "komodor_kubernetes" "data_k8s_cluster" {
 
 // the output below represents the API key used to onboard a cluster to app.komodor.com
 output "cluster_api_key" {
-  value = komodor_kubernetes.k8s_cluster.id
+  value     = komodor_kubernetes.k8s_cluster.api_key
+  sensitive = true
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+resource "komodor_service_account" "ci" {
+  name        = "github-actions"
+  description = "Deployment pipelines"
+}
+
+# Rotated every 90 days, and whenever the CI secret store is migrated.
+resource "komodor_api_key" "ci" {
+  service_account_id = komodor_service_account.ci.id
+  name               = "github-actions"
+  rotation_days      = 90
+
+  keepers = {
+    secret_store = "vault-prod"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "ci_api_key" {
+  value     = komodor_api_key.ci.key
+  sensitive = true
+}
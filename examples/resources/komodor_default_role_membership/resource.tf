@@ -0,0 +1,19 @@
+resource "komodor_policy_v2" "staging_deploy" {
+  name = "staging-deploy"
+
+  statements {
+    actions = ["edit:deployments"]
+    resources_scope {
+      clusters = ["staging"]
+    }
+  }
+}
+
+# Bind users to the built-in account-admin role and extend it with a policy.
+# Destroying this resource unbinds the users and detaches the policy, but
+# never deletes the role.
+resource "komodor_default_role_membership" "account_admin" {
+  role_name = "account-admin"
+  members   = ["alice@example.com", "bob@example.com"]
+  policies  = [komodor_policy_v2.staging_deploy.id]
+}
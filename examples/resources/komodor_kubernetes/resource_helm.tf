@@ -0,0 +1,20 @@
+resource "komodor_kubernetes" "prod" {
+  cluster_name = "prod-eu"
+}
+
+# Install the Komodor agent with the Helm provider
+resource "helm_release" "komodor_agent" {
+  name             = "komodor-agent"
+  repository       = "https://helm-charts.komodor.io"
+  chart            = "komodor-agent"
+  namespace        = "komodor"
+  create_namespace = true
+
+  values = [komodor_kubernetes.prod.helm_values]
+}
+
+# Or print the equivalent install command
+output "komodor_agent_install_command" {
+  value     = komodor_kubernetes.prod.install_command
+  sensitive = true
+}
@@ -0,0 +1,20 @@
+resource "komodor_kubernetes" "prod" {
+  cluster_name = "prod-eu"
+
+  # Bump the version to rotate the agent key in place
+  keepers = {
+    version = "2"
+  }
+
+  # Keep the previous key working for two days while the agent is rolled out
+  rotation_grace_period_hours = 48
+}
+
+# Roll the agent out with the new key, falling back to the previous one
+output "agent_keys" {
+  value = {
+    current  = komodor_kubernetes.prod.api_key
+    previous = komodor_kubernetes.prod.previous_api_key
+  }
+  sensitive = true
+}
@@ -0,0 +1,16 @@
+resource "komodor_kubernetes" "prod" {
+  cluster_name = "prod-eu"
+  description  = "Production cluster in Frankfurt"
+
+  labels = {
+    env    = "production"
+    region = "eu"
+  }
+
+  agent_features {
+    metrics        = true
+    network_mapper = false
+    actions        = true
+    logs_redaction = true
+  }
+}
@@ -0,0 +1,37 @@
+resource "komodor_kubernetes" "prod" {
+  cluster_name = "prod-eu"
+}
+
+resource "helm_release" "komodor_agent" {
+  name             = "komodor-agent"
+  repository       = "https://helm-charts.komodor.io"
+  chart            = "komodor-agent"
+  namespace        = "komodor"
+  create_namespace = true
+
+  values = [komodor_kubernetes.prod.helm_values]
+}
+
+# Wait for the agent to report before creating anything that needs it
+resource "komodor_kubernetes_ready" "prod" {
+  cluster_name = komodor_kubernetes.prod.cluster_name
+
+  # Wait again whenever the agent is upgraded
+  triggers = {
+    agent_revision = helm_release.komodor_agent.metadata[0].revision
+  }
+
+  timeouts {
+    create = "15m"
+  }
+}
+
+resource "komodor_monitor" "availability" {
+  name   = "prod-eu-availability"
+  type   = "availability"
+  active = true
+  sensors = jsonencode([{
+    cluster    = komodor_kubernetes_ready.prod.cluster_name
+    namespaces = ["default"]
+  }])
+}
@@ -0,0 +1,31 @@
+resource "komodor_policy_v2" "view_except_secrets" {
+  name = "view-except-secrets"
+
+  statements {
+    actions = ["view:all"]
+
+    resources_scope {
+      clusters_patterns {
+        include = "*"
+        exclude = ""
+      }
+    }
+  }
+
+  statements {
+    actions = ["view:all"]
+    effect  = "deny"
+
+    resources_scope {
+      clusters_patterns {
+        include = "*"
+        exclude = ""
+      }
+
+      namespaces_patterns {
+        include = "secrets-*"
+        exclude = ""
+      }
+    }
+  }
+}
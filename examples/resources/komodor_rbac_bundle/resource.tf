@@ -0,0 +1,24 @@
+resource "komodor_rbac_bundle" "payments" {
+  role_name        = "payments-team"
+  role_description = "Access for the payments team"
+
+  statements {
+    actions = ["view:all"]
+    resources_scope {
+      clusters_patterns {
+        include = "prod-*"
+        exclude = ""
+      }
+      namespaces = ["payments", "billing"]
+    }
+  }
+
+  members = [
+    "alice@example.com",
+    "bob@example.com",
+  ]
+}
+
+output "payments_role_id" {
+  value = komodor_rbac_bundle.payments.role_id
+}
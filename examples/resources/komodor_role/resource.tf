@@ -1,3 +1,7 @@
 resource "komodor_role" "my-role" {
-  name = "my-role"
-}
\ No newline at end of file
+  name        = "my-role"
+  description = "Read-only access for the payments team"
+  metadata = {
+    team = "payments"
+  }
+}
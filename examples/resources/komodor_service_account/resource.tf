@@ -0,0 +1,9 @@
+data "komodor_role" "viewer" {
+  name = "viewer"
+}
+
+resource "komodor_service_account" "ci" {
+  name        = "github-actions"
+  description = "Deployment pipelines"
+  roles       = [data.komodor_role.viewer.id]
+}
@@ -0,0 +1,21 @@
+data "komodor_role" "viewer" {
+  name = "viewer"
+}
+
+resource "komodor_role" "platform_oncall" {
+  name = "platform-oncall"
+}
+
+# Members of the "platform-engineers" group in the identity provider are
+# granted both roles when they sign in through SSO.
+resource "komodor_sso_group_role_mapping" "platform" {
+  group_name = "platform-engineers"
+  roles      = [data.komodor_role.viewer.id, komodor_role.platform_oncall.id]
+}
+
+# Contractors get view access until the end of their engagement.
+resource "komodor_sso_group_role_mapping" "contractors" {
+  group_name = "contractors"
+  roles      = [data.komodor_role.viewer.id]
+  expires_at = "2027-01-01T00:00:00Z"
+}
@@ -0,0 +1,13 @@
+# Destroying this resource leaves the user in Komodor, so that offboarding
+# is handled by the identity team rather than by Terraform. A user deleted
+# earlier is not restored; creation fails instead.
+resource "komodor_user" "contractor" {
+  display_name       = "Contractor"
+  email              = "contractor@example.com"
+  restore_if_deleted = false
+  deletion_policy    = "retain"
+}
+
+output "contractor_role_ids" {
+  value = komodor_user.contractor.roles[*].id
+}
@@ -0,0 +1,9 @@
+# Roles can be referred to by name instead of looking up their IDs.
+resource "komodor_user_role_binding" "by_name" {
+  name    = "oncall-user-binding"
+  user_id = "user@example.com"
+  roles = [
+    "name:viewer",
+    "name:platform-oncall",
+  ]
+}
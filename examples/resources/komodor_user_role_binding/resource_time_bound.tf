@@ -0,0 +1,18 @@
+# Break-glass access to production that lapses after 4 hours
+resource "komodor_user_role_binding" "break_glass" {
+  name    = "oncall-break-glass"
+  user_id = "oncall@example.com"
+  roles   = ["production-admin-role-id"]
+  ttl     = "4h"
+
+  # Once the grant has lapsed, leave it lapsed instead of re-granting it on the next apply
+  on_expiration = "drop"
+}
+
+# Access that ends at a fixed point in time
+resource "komodor_user_role_binding" "contractor" {
+  name       = "contractor-binding"
+  user_id    = "contractor@example.com"
+  roles      = ["developer-role-id"]
+  expires_at = "2026-12-31T23:59:59Z"
+}
@@ -0,0 +1,6 @@
+resource "komodor_user_set" "platform_team" {
+  users = {
+    "alice@example.com" = "Alice Smith"
+    "bob@example.com"   = "Bob Jones"
+  }
+}
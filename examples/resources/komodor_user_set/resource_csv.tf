@@ -0,0 +1,11 @@
+# users.csv:
+#   email,display_name
+#   alice@example.com,Alice Smith
+#   bob@example.com,Bob Jones
+locals {
+  users = csvdecode(file("${path.module}/users.csv"))
+}
+
+resource "komodor_user_set" "from_csv" {
+  users = { for u in local.users : u.email => u.display_name }
+}
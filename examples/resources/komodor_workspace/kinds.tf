@@ -0,0 +1,27 @@
+# A workspace with every resource in a group of clusters
+resource "komodor_workspace" "production" {
+  name        = "production"
+  description = "All production clusters"
+  kind        = "cluster_group"
+
+  cluster_group {
+    clusters = ["legacy-prod"]
+    clusters_patterns {
+      include = "prod-*"
+      exclude = "prod-backup-*"
+    }
+  }
+}
+
+# A workspace with every resource in the clusters carrying these labels
+resource "komodor_workspace" "eu_production" {
+  name = "eu-production"
+  kind = "label_selector"
+
+  label_selector {
+    match_labels = {
+      env    = "production"
+      region = "eu"
+    }
+  }
+}
@@ -0,0 +1,30 @@
+resource "komodor_role" "payments" {
+  name = "payments-team"
+}
+
+resource "komodor_workspace" "payments" {
+  name        = "payments"
+  description = "Payments services in every production cluster"
+
+  scopes {
+    clusters_patterns {
+      include = "prod-*"
+      exclude = ""
+    }
+    namespaces = ["payments"]
+  }
+}
+
+# Members of the payments-team role land in the payments workspace when they
+# sign in; the SRE on call can open it too.
+resource "komodor_workspace_access" "payments" {
+  workspace_id      = komodor_workspace.payments.id
+  roles             = [komodor_role.payments.id]
+  default_for_roles = [komodor_role.payments.id]
+  users             = [komodor_user.sre_oncall.id]
+}
+
+resource "komodor_user" "sre_oncall" {
+  email        = "sre-oncall@example.com"
+  display_name = "SRE On-Call"
+}
@@ -0,0 +1,135 @@
+package scope
+
+import "strings"
+
+// The Covers family answers "is every resource matched by the argument also
+// matched by the receiver?". Globs cannot be compared in general, so the
+// answer is conservative: true means the containment is certain, false means
+// it is either false or could not be proven.
+
+// Validate reports an error when the include or exclude glob is invalid.
+func (p Pattern) Validate() error {
+	for _, glob := range []string{p.Include, p.Exclude} {
+		if glob == "" {
+			continue
+		}
+		if _, err := CompilePattern(glob); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchesNothing reports whether no value can ever match the pattern, either
+// because include is empty or because exclude removes everything include
+// matches.
+func (p Pattern) MatchesNothing() bool {
+	if p.Include == "" {
+		return true
+	}
+	return p.Exclude != "" && globCovers(p.Exclude, p.Include)
+}
+
+// Covers reports whether every value matched by q is also matched by p.
+func (p Pattern) Covers(q Pattern) bool {
+	if q.MatchesNothing() {
+		return true
+	}
+	if !globCovers(p.Include, q.Include) {
+		return false
+	}
+	return p.Exclude == "" || (q.Exclude != "" && globCovers(q.Exclude, p.Exclude))
+}
+
+// Covers reports whether every value allowed by e is also allowed by d.
+func (d Dimension) Covers(e Dimension) bool {
+	if d.IsEmpty() {
+		return true
+	}
+	if e.IsEmpty() {
+		return false
+	}
+	for _, name := range e.Names {
+		ok, err := d.Match(name)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	for _, q := range e.Patterns {
+		covered := false
+		for _, p := range d.Patterns {
+			if p.Covers(q) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// Covers reports whether every resource within t is also within s.
+func (s Scope) Covers(t Scope) bool {
+	if !s.Clusters.Covers(t.Clusters) ||
+		!s.Namespaces.Covers(t.Namespaces) ||
+		!s.Workloads.Covers(t.Workloads) ||
+		!s.ResourceTypes.Covers(t.ResourceTypes) {
+		return false
+	}
+
+	// A selector on s narrows it, so t must require at least as much.
+	for _, sel := range s.Selectors {
+		found := false
+		for _, other := range t.Selectors {
+			if other == sel {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, sp := range s.SelectorPatterns {
+		if !t.requiresSelectorPattern(sp) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Scope) requiresSelectorPattern(sp SelectorPattern) bool {
+	for _, sel := range s.Selectors {
+		if sel.Key != sp.Key || sel.Type != sp.Type {
+			continue
+		}
+		if ok, err := sp.Value.Match(sel.Value); err == nil && ok {
+			return true
+		}
+	}
+	for _, other := range s.SelectorPatterns {
+		if other.Key == sp.Key && other.Type == sp.Type && sp.Value.Covers(other.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// globCovers reports whether every value matched by inner is matched by
+// outer. Only identical globs, "*" and literal prefixes followed by "*" are
+// recognised.
+func globCovers(outer, inner string) bool {
+	if outer == "*" || outer == inner {
+		return true
+	}
+	if !strings.HasSuffix(outer, "*") {
+		return false
+	}
+	prefix := strings.TrimSuffix(outer, "*")
+	if strings.ContainsAny(prefix, "*?[") {
+		return false
+	}
+	return strings.HasPrefix(inner, prefix)
+}
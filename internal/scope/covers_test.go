@@ -0,0 +1,116 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternMatchesNothing(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern Pattern
+		want    bool
+	}{
+		{name: "empty include", pattern: Pattern{}, want: true},
+		{name: "exclude everything", pattern: Pattern{Include: "prod-*", Exclude: "*"}, want: true},
+		{name: "exclude equals include", pattern: Pattern{Include: "prod-*", Exclude: "prod-*"}, want: true},
+		{name: "exclude is a wider prefix", pattern: Pattern{Include: "prod-eu-*", Exclude: "prod-*"}, want: true},
+		{name: "exclude is narrower", pattern: Pattern{Include: "prod-*", Exclude: "prod-legacy-*"}, want: false},
+		{name: "no exclude", pattern: Pattern{Include: "*"}, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.pattern.MatchesNothing())
+		})
+	}
+}
+
+func TestPatternValidate(t *testing.T) {
+	assert.NoError(t, Pattern{Include: "prod-*", Exclude: "prod-[ab]"}.Validate())
+	assert.Error(t, Pattern{Include: "prod-["}.Validate())
+	assert.Error(t, Pattern{Include: "*", Exclude: "[z-a]"}.Validate())
+}
+
+func TestScopeCovers(t *testing.T) {
+	tests := []struct {
+		name  string
+		outer Scope
+		inner Scope
+		want  bool
+	}{
+		{
+			name:  "unrestricted covers anything",
+			outer: Scope{},
+			inner: Scope{Clusters: Dimension{Names: []string{"prod"}}},
+			want:  true,
+		},
+		{
+			name:  "restricted does not cover unrestricted",
+			outer: Scope{Clusters: Dimension{Names: []string{"prod"}}},
+			inner: Scope{},
+			want:  false,
+		},
+		{
+			name:  "names subset",
+			outer: Scope{Clusters: Dimension{Names: []string{"prod", "staging"}}},
+			inner: Scope{Clusters: Dimension{Names: []string{"prod"}}},
+			want:  true,
+		},
+		{
+			name:  "names matched by pattern",
+			outer: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "prod-*"}}}},
+			inner: Scope{Clusters: Dimension{Names: []string{"prod-eu", "prod-us"}}},
+			want:  true,
+		},
+		{
+			name:  "narrower prefix pattern",
+			outer: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "prod-*"}}}},
+			inner: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "prod-eu-*"}}}},
+			want:  true,
+		},
+		{
+			name:  "outer exclude not carried by inner",
+			outer: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "*", Exclude: "prod-*"}}}},
+			inner: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "*"}}}},
+			want:  false,
+		},
+		{
+			name:  "inner excludes more",
+			outer: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "*", Exclude: "prod-eu-*"}}}},
+			inner: Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "*", Exclude: "prod-*"}}}},
+			want:  true,
+		},
+		{
+			name:  "different namespaces",
+			outer: Scope{Namespaces: Dimension{Names: []string{"payments"}}},
+			inner: Scope{Namespaces: Dimension{Names: []string{"billing"}}},
+			want:  false,
+		},
+		{
+			name:  "outer selector required by inner",
+			outer: Scope{Selectors: []Selector{{Key: "team", Type: "label", Value: "a"}}},
+			inner: Scope{Clusters: Dimension{Names: []string{"prod"}}, Selectors: []Selector{{Key: "team", Type: "label", Value: "a"}}},
+			want:  true,
+		},
+		{
+			name:  "outer selector missing from inner",
+			outer: Scope{Selectors: []Selector{{Key: "team", Type: "label", Value: "a"}}},
+			inner: Scope{Clusters: Dimension{Names: []string{"prod"}}},
+			want:  false,
+		},
+		{
+			name:  "outer selector pattern satisfied by inner selector",
+			outer: Scope{SelectorPatterns: []SelectorPattern{{Key: "team", Type: "label", Value: Pattern{Include: "team-*"}}}},
+			inner: Scope{Selectors: []Selector{{Key: "team", Type: "label", Value: "team-a"}}},
+			want:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.outer.Covers(tc.inner))
+		})
+	}
+}
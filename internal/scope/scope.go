@@ -0,0 +1,242 @@
+// Package scope evaluates Komodor resource scopes locally.
+//
+// RBAC policy statements, workspaces and right-sizing policies all describe
+// the resources they apply to with the same building blocks: per-dimension
+// lists of exact names, include/exclude glob patterns and label or
+// annotation selectors. This package matches a single resource against such
+// a scope.
+//
+// Semantics:
+//   - A dimension matches when the value equals one of the exact names or
+//     matches any of the patterns. Exact names are literal, so "*" only
+//     matches a resource named "*".
+//   - A pattern matches when the value matches Include and does not match
+//     Exclude. An empty Include matches nothing; an empty Exclude excludes
+//     nothing.
+//   - Patterns are shell-style globs: `*` matches any run of characters, `?`
+//     a single character, and `[...]` / `[!...]` a character class. Matching
+//     is case-sensitive and anchored to the whole value.
+//   - A dimension with neither names nor patterns is unrestricted.
+//   - Every dimension, selector and selector pattern of a scope must match.
+//   - Dimensions left empty in the Query are not evaluated by Matches, so a
+//     query without a namespace asks whether any part of the cluster is in
+//     scope. Selectors are only evaluated when the query carries labels or
+//     annotations. Contains is the strict counterpart: it only reports true
+//     when the scope covers everything the query describes.
+package scope
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SelectorTypeAnnotation marks a selector that applies to annotations;
+// selectors of any other type apply to labels.
+const SelectorTypeAnnotation = "annotation"
+
+// Pattern is an include/exclude pair of glob patterns.
+type Pattern struct {
+	Include string
+	Exclude string
+}
+
+// Dimension restricts one attribute of a resource, such as its cluster.
+type Dimension struct {
+	Names    []string
+	Patterns []Pattern
+}
+
+// Selector requires a label or annotation to have an exact value.
+type Selector struct {
+	Key   string
+	Type  string
+	Value string
+}
+
+// SelectorPattern requires a label or annotation value to match a pattern.
+type SelectorPattern struct {
+	Key   string
+	Type  string
+	Value Pattern
+}
+
+// Scope is the set of resources a policy statement, workspace or right-sizing
+// policy applies to.
+type Scope struct {
+	Clusters         Dimension
+	Namespaces       Dimension
+	Workloads        Dimension
+	ResourceTypes    Dimension
+	Selectors        []Selector
+	SelectorPatterns []SelectorPattern
+}
+
+// Query describes the resource to evaluate.
+type Query struct {
+	Cluster      string
+	Namespace    string
+	Workload     string
+	ResourceType string
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+// CompilePattern turns a glob into an anchored regular expression.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid pattern %q: unterminated character class", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// Match reports whether value is matched by the include glob and not by the
+// exclude glob.
+func (p Pattern) Match(value string) (bool, error) {
+	if p.Include == "" {
+		return false, nil
+	}
+	include, err := CompilePattern(p.Include)
+	if err != nil {
+		return false, err
+	}
+	if !include.MatchString(value) {
+		return false, nil
+	}
+	if p.Exclude == "" {
+		return true, nil
+	}
+	exclude, err := CompilePattern(p.Exclude)
+	if err != nil {
+		return false, err
+	}
+	return !exclude.MatchString(value), nil
+}
+
+// IsEmpty reports whether the dimension is unrestricted.
+func (d Dimension) IsEmpty() bool {
+	return len(d.Names) == 0 && len(d.Patterns) == 0
+}
+
+// Match reports whether value falls within the dimension.
+func (d Dimension) Match(value string) (bool, error) {
+	if d.IsEmpty() {
+		return true, nil
+	}
+	for _, name := range d.Names {
+		if name == value {
+			return true, nil
+		}
+	}
+	for _, p := range d.Patterns {
+		ok, err := p.Match(value)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (q Query) selectorSource(selectorType string) map[string]string {
+	if selectorType == SelectorTypeAnnotation {
+		return q.Annotations
+	}
+	return q.Labels
+}
+
+// Matches reports whether the queried resource falls within the scope.
+func (s Scope) Matches(q Query) (bool, error) {
+	dims := []struct {
+		dim   Dimension
+		value string
+	}{
+		{s.Clusters, q.Cluster},
+		{s.Namespaces, q.Namespace},
+		{s.Workloads, q.Workload},
+		{s.ResourceTypes, q.ResourceType},
+	}
+	for _, d := range dims {
+		if d.value == "" {
+			continue
+		}
+		ok, err := d.dim.Match(d.value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if q.Labels == nil && q.Annotations == nil {
+		return true, nil
+	}
+
+	for _, sel := range s.Selectors {
+		if v, found := q.selectorSource(sel.Type)[sel.Key]; !found || v != sel.Value {
+			return false, nil
+		}
+	}
+	for _, sp := range s.SelectorPatterns {
+		v, found := q.selectorSource(sp.Type)[sp.Key]
+		if !found {
+			return false, nil
+		}
+		ok, err := sp.Value.Match(v)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Contains reports whether every resource described by the query falls
+// within the scope. Unlike Matches, a dimension left empty in the query only
+// passes when the scope leaves it unrestricted, and selectors only pass when
+// the query carries the labels or annotations they require. It is used for
+// deny statements, which must not deny a whole cluster because they deny one
+// of its namespaces.
+func (s Scope) Contains(q Query) (bool, error) {
+	dims := []struct {
+		dim   Dimension
+		value string
+	}{
+		{s.Clusters, q.Cluster},
+		{s.Namespaces, q.Namespace},
+		{s.Workloads, q.Workload},
+		{s.ResourceTypes, q.ResourceType},
+	}
+	for _, d := range dims {
+		if d.value == "" && !d.dim.IsEmpty() {
+			return false, nil
+		}
+	}
+	if (len(s.Selectors) > 0 || len(s.SelectorPatterns) > 0) && q.Labels == nil && q.Annotations == nil {
+		return false, nil
+	}
+	return s.Matches(q)
+}
@@ -0,0 +1,163 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern Pattern
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{name: "wildcard include", pattern: Pattern{Include: "*"}, value: "anything", want: true},
+		{name: "wildcard include matches empty-looking names", pattern: Pattern{Include: "*"}, value: "-", want: true},
+		{name: "prefix include", pattern: Pattern{Include: "prod-*"}, value: "prod-eu", want: true},
+		{name: "prefix include mismatch", pattern: Pattern{Include: "prod-*"}, value: "staging-eu", want: false},
+		{name: "exclude wins over include", pattern: Pattern{Include: "prod-*", Exclude: "prod-legacy-*"}, value: "prod-legacy-1", want: false},
+		{name: "exclude does not match", pattern: Pattern{Include: "prod-*", Exclude: "prod-legacy-*"}, value: "prod-eu", want: true},
+		{name: "empty include matches nothing", pattern: Pattern{Include: ""}, value: "prod", want: false},
+		{name: "question mark matches one character", pattern: Pattern{Include: "team-?"}, value: "team-a", want: true},
+		{name: "question mark does not match two characters", pattern: Pattern{Include: "team-?"}, value: "team-ab", want: false},
+		{name: "character class", pattern: Pattern{Include: "ns-[ab]"}, value: "ns-b", want: true},
+		{name: "negated character class", pattern: Pattern{Include: "ns-[!ab]"}, value: "ns-b", want: false},
+		{name: "regex metacharacters are literal", pattern: Pattern{Include: "a.b"}, value: "axb", want: false},
+		{name: "matching is case-sensitive", pattern: Pattern{Include: "Prod-*"}, value: "prod-eu", want: false},
+		{name: "whole value must match", pattern: Pattern{Include: "prod"}, value: "prod-eu", want: false},
+		{name: "unterminated class is an error", pattern: Pattern{Include: "ns-["}, value: "ns-a", wantErr: true},
+		{name: "empty class is an error", pattern: Pattern{Include: "ns-[]"}, value: "ns-a", wantErr: true},
+		{name: "invalid exclude is an error", pattern: Pattern{Include: "*", Exclude: "[z-a]"}, value: "ns-a", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.pattern.Match(tc.value)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDimensionMatch(t *testing.T) {
+	dim := Dimension{Names: []string{"*", "exact"}, Patterns: []Pattern{{Include: "team-*"}}}
+
+	for value, want := range map[string]bool{
+		"exact":   true,
+		"team-a":  true,
+		"*":       true,
+		"another": false,
+	} {
+		got, err := dim.Match(value)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, value)
+	}
+
+	got, err := Dimension{}.Match("anything")
+	assert.NoError(t, err)
+	assert.True(t, got, "an empty dimension is unrestricted")
+}
+
+func TestScopeMatches(t *testing.T) {
+	s := Scope{
+		Clusters:      Dimension{Names: []string{"prod-us"}},
+		Namespaces:    Dimension{Patterns: []Pattern{{Include: "team-*", Exclude: "team-internal"}}},
+		Workloads:     Dimension{Patterns: []Pattern{{Include: "api-*"}}},
+		ResourceTypes: Dimension{Names: []string{"Deployment"}},
+		Selectors:     []Selector{{Key: "env", Type: "label", Value: "production"}},
+		SelectorPatterns: []SelectorPattern{
+			{Key: "owner", Type: SelectorTypeAnnotation, Value: Pattern{Include: "team-*"}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{name: "cluster and namespace match", query: Query{Cluster: "prod-us", Namespace: "team-a"}, want: true},
+		{name: "cluster mismatch", query: Query{Cluster: "prod-eu", Namespace: "team-a"}, want: false},
+		{name: "excluded namespace", query: Query{Cluster: "prod-us", Namespace: "team-internal"}, want: false},
+		{name: "cluster-wide query skips namespaces", query: Query{Cluster: "prod-us"}, want: true},
+		{name: "workload matches", query: Query{Cluster: "prod-us", Workload: "api-gateway", ResourceType: "Deployment"}, want: true},
+		{name: "workload mismatch", query: Query{Cluster: "prod-us", Workload: "worker"}, want: false},
+		{name: "resource type mismatch", query: Query{Cluster: "prod-us", ResourceType: "StatefulSet"}, want: false},
+		{
+			name: "selectors match",
+			query: Query{
+				Cluster:     "prod-us",
+				Labels:      map[string]string{"env": "production"},
+				Annotations: map[string]string{"owner": "team-payments"},
+			},
+			want: true,
+		},
+		{name: "missing annotation", query: Query{Cluster: "prod-us", Labels: map[string]string{"env": "production"}}, want: false},
+		{
+			name: "selector type is respected",
+			query: Query{
+				Cluster:     "prod-us",
+				Labels:      map[string]string{"owner": "team-payments"},
+				Annotations: map[string]string{"env": "production"},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.Matches(tc.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestScopeMatches_EmptyScope(t *testing.T) {
+	got, err := Scope{}.Matches(Query{Cluster: "any", Namespace: "any", Labels: map[string]string{"a": "b"}})
+	assert.NoError(t, err)
+	assert.True(t, got, "a scope without constraints matches everything")
+}
+
+func TestScopeMatches_PropagatesPatternErrors(t *testing.T) {
+	s := Scope{Clusters: Dimension{Patterns: []Pattern{{Include: "prod-["}}}}
+	_, err := s.Matches(Query{Cluster: "prod-us"})
+	assert.Error(t, err)
+}
+
+func TestScopeContains(t *testing.T) {
+	s := Scope{
+		Clusters:   Dimension{Names: []string{"prod"}},
+		Namespaces: Dimension{Patterns: []Pattern{{Include: "secrets-*"}}},
+	}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{name: "fully described resource in scope", query: Query{Cluster: "prod", Namespace: "secrets-a"}, want: true},
+		{name: "namespace out of scope", query: Query{Cluster: "prod", Namespace: "default"}, want: false},
+		{name: "whole cluster is not contained", query: Query{Cluster: "prod"}, want: false},
+		{name: "unrestricted dimension may be omitted", query: Query{Cluster: "prod", Namespace: "secrets-a", Workload: "api"}, want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.Contains(tc.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	withSelector := Scope{Selectors: []Selector{{Key: "team", Type: "label", Value: "a"}}}
+	got, err := withSelector.Contains(Query{Cluster: "prod"})
+	assert.NoError(t, err)
+	assert.False(t, got, "selectors cannot be checked without labels")
+}
@@ -13,6 +13,8 @@ type Client struct {
 	HttpClient *http.Client
 	ApiKey     string
 	BaseURL    string
+
+	roleIndex roleIndex
 }
 
 type ApiKeyResponse struct {
@@ -47,6 +49,16 @@ func (c *Client) GetRolesUrl() string {
 	return c.GetV2Endpoint() + "/rbac/roles"
 }
 
+// GetServiceAccountsUrl returns the service accounts endpoint
+func (c *Client) GetServiceAccountsUrl() string {
+	return c.GetV2Endpoint() + "/service-accounts"
+}
+
+// GetApiKeysUrl returns the API keys endpoint
+func (c *Client) GetApiKeysUrl() string {
+	return c.GetV2Endpoint() + "/api-keys"
+}
+
 // GetPoliciesUrlV2 returns the v2 policies endpoint
 func (c *Client) GetPoliciesUrlV2() string {
 	return c.GetV2Endpoint() + "/rbac/policies"
@@ -77,6 +89,18 @@ func (c *Client) GetUserRoleBindingUrl() string {
 	return c.GetV2Endpoint() + "/rbac/users/roles"
 }
 
+// GetSsoGroupsUrl returns the SSO groups endpoint
+func (c *Client) GetSsoGroupsUrl() string {
+	return c.GetV2Endpoint() + "/rbac/sso/groups"
+}
+
+// GetSsoGroupRoleMappingUrl returns the SSO group role mapping endpoint. It is
+// not nested under the groups endpoint, where it would shadow a group named
+// "roles".
+func (c *Client) GetSsoGroupRoleMappingUrl() string {
+	return c.GetV2Endpoint() + "/rbac/sso/group-roles"
+}
+
 // GetKlaudiaV2Endpoint returns the Klaudia API v2 endpoint, served by
 // public-api under /api/v2/klaudia and proxied to ai-investigator.
 func (c *Client) GetKlaudiaV2Endpoint() string {
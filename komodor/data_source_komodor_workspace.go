@@ -6,63 +6,56 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceKomodorWorkspace() *schema.Resource {
 	return &schema.Resource{
-		Description: "Get information about a Komodor workspace",
+		Description: "Get information about a Komodor workspace, by ID or by name",
 		ReadContext: dataSourceKomodorWorkspaceRead,
 
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the workspace",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "The ID of the workspace. Exactly one of `id` and `name` must be set.",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the workspace",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the workspace. Reading fails unless exactly one workspace has this name.",
 			},
 			"description": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The description of the workspace",
 			},
+			"kind": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kind of the workspace: scopes, cluster_group, label_selector, or a kind this provider does not support yet",
+			},
 			"scopes": {
 				Type:        schema.TypeList,
 				Computed:    true,
 				Description: "The scopes of the workspace",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"clusters": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"namespaces": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"clusters_patterns":   patternListComputedSchema(),
-						"namespaces_patterns": patternListComputedSchema(),
-						"selectors": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem:     selectorSchema(),
-						},
-						"selectors_patterns": {
-							Type:     schema.TypeList,
-							Computed: true,
-							Elem:     selectorPatternSchema(),
-						},
-					},
-				},
+				Elem:        resourcesScopeComputedResource(),
+			},
+			"cluster_group": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The clusters of a cluster_group workspace",
+				Elem:        workspaceClusterGroupComputedResource(),
+			},
+			"label_selector": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The cluster label selector of a label_selector workspace",
+				Elem:        workspaceLabelSelectorComputedResource(),
 			},
 			"created_at": {
 				Type:        schema.TypeString,
@@ -90,18 +83,24 @@ func dataSourceKomodorWorkspace() *schema.Resource {
 
 func dataSourceKomodorWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
-	id := d.Get("id").(string)
 
-	workspace, statusCode, err := client.GetWorkspace(id)
-	if err != nil {
-		if statusCode == 404 {
+	var workspace *Workspace
+	var err error
+	if id := d.Get("id").(string); id != "" {
+		var statusCode int
+		workspace, statusCode, err = client.GetWorkspace(id)
+		if err != nil && statusCode == 404 {
 			log.Printf("[DEBUG] Workspace (%s) was not found", id)
 			return diag.Errorf("Workspace not found: %s", id)
 		}
+	} else {
+		workspace, err = client.GetWorkspaceByName(d.Get("name").(string))
+	}
+	if err != nil {
 		return diag.Errorf("Error reading Workspace: %s", err)
 	}
 
-	d.SetId(id)
+	d.SetId(workspace.Id)
 
 	if err := flattenWorkspace(workspace, d); err != nil {
 		return diag.Errorf("Error flattening workspace: %s", err)
@@ -109,3 +108,67 @@ func dataSourceKomodorWorkspaceRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func workspaceClusterGroupComputedResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clusters_patterns": patternListComputedSchema(),
+		},
+	}
+}
+
+func workspaceLabelSelectorComputedResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"match_labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourcesScopeComputedResource is the read-only counterpart of the
+// resources scope blocks used by policies and workspaces.
+func resourcesScopeComputedResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"namespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clusters_patterns":   patternListComputedSchema(),
+			"namespaces_patterns": patternListComputedSchema(),
+			"selectors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     selectorSchema(),
+			},
+			"selectors_patterns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     selectorPatternSchema(),
+			},
+		},
+	}
+}
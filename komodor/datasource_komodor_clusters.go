@@ -0,0 +1,154 @@
+package komodor
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+const (
+	clusterConnectionAll          = "all"
+	clusterConnectionConnected    = "connected"
+	clusterConnectionDisconnected = "disconnected"
+)
+
+func dataSourceKomodorClusters() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorClustersRead,
+		Description: "Lists the Kubernetes clusters onboarded to Komodor, with the status their agents last reported",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression the cluster name must match. Unanchored unless `^` and `$` are used.",
+			},
+			"connection_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      clusterConnectionAll,
+				ValidateFunc: validation.StringInSlice([]string{clusterConnectionAll, clusterConnectionConnected, clusterConnectionDisconnected}, false),
+				Description:  "Restrict the results to clusters whose agent is `connected` or `disconnected`. Defaults to `all`.",
+			},
+			"cluster_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the matching clusters, in the same order as `clusters`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clusters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching clusters, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"connected": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the agent is connected to Komodor.",
+						},
+						"last_heartbeat": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the agent last reported. Empty if it never has.",
+						},
+						"agent_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kubernetes_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type clustersListFilter struct {
+	nameRegex        *regexp.Regexp
+	connectionStatus string
+}
+
+func (f clustersListFilter) matches(k Kubernetes) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(k.ClusterName) {
+		return false
+	}
+	switch f.connectionStatus {
+	case clusterConnectionConnected:
+		return k.Connected
+	case clusterConnectionDisconnected:
+		return !k.Connected
+	}
+	return true
+}
+
+func flattenListedCluster(k Kubernetes) map[string]interface{} {
+	return map[string]interface{}{
+		"cluster_name":       k.ClusterName,
+		"description":        k.Description,
+		"labels":             k.Labels,
+		"connected":          k.Connected,
+		"last_heartbeat":     k.LastHeartbeat,
+		"agent_version":      k.AgentVersion,
+		"kubernetes_version": k.KubernetesVersion,
+	}
+}
+
+func dataSourceKomodorClustersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	filter := clustersListFilter{connectionStatus: d.Get("connection_status").(string)}
+	if expr := d.Get("name_regex").(string); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		filter.nameRegex = re
+	}
+
+	clusters, err := client.GetKubernetesClusters()
+	if err != nil {
+		return diag.Errorf("Error listing Kubernetes clusters: %s", err)
+	}
+
+	matching := lo.Filter(clusters, func(k Kubernetes, _ int) bool { return filter.matches(k) })
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].ClusterName < matching[j].ClusterName })
+
+	names := lo.Map(matching, func(k Kubernetes, _ int) string { return k.ClusterName })
+	d.SetId(rbacListId(names))
+	if err := d.Set("cluster_names", names); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("clusters", lo.Map(matching, func(k Kubernetes, _ int) interface{} {
+		return flattenListedCluster(k)
+	})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package komodor
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_clusters") }
+
+func TestAcc_datasource_komodor_clusters(t *testing.T) {
+	clusterName := testResourceName("ds-clusters")
+	resourceAddr := "data.komodor_clusters.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceClustersConfig(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "clusters.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "cluster_names.0", clusterName),
+					// No agent is installed, so the cluster never connects.
+					resource.TestCheckResourceAttr(resourceAddr, "clusters.0.connected", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceClustersConfig(clusterName string) string {
+	return fmt.Sprintf(`
+resource "komodor_kubernetes" "test" {
+  cluster_name = %q
+}
+
+data "komodor_clusters" "test" {
+  name_regex        = "^%s$"
+  connection_status = "disconnected"
+  depends_on        = [komodor_kubernetes.test]
+}
+`, clusterName, regexp.QuoteMeta(clusterName))
+}
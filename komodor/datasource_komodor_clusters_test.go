@@ -0,0 +1,63 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceClusters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/integrations/kubernetes" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"apiKey": "key-3", "clusterName": "staging", "isConnected": false, "lastHeartbeat": "2026-10-01T08:00:00Z", "agentVersion": "2.9.0", "k8sVersion": "1.29"},
+			{"apiKey": "key-1", "clusterName": "prod-us", "isConnected": true, "lastHeartbeat": "2026-10-19T10:00:00Z", "agentVersion": "2.11.1", "k8sVersion": "1.31", "labels": {"env": "prod"}},
+			{"apiKey": "key-2", "clusterName": "prod-eu", "isConnected": true, "agentVersion": "2.11.0", "k8sVersion": "1.30"},
+			{"apiKey": "key-4", "clusterName": "new"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	r := dataSourceKomodorClusters()
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   []interface{}
+	}{
+		{name: "all", config: map[string]interface{}{}, want: []interface{}{"new", "prod-eu", "prod-us", "staging"}},
+		{name: "connected", config: map[string]interface{}{"connection_status": "connected"}, want: []interface{}{"prod-eu", "prod-us"}},
+		{name: "disconnected", config: map[string]interface{}{"connection_status": "disconnected"}, want: []interface{}{"new", "staging"}},
+		{name: "name regex", config: map[string]interface{}{"name_regex": "^prod-"}, want: []interface{}{"prod-eu", "prod-us"}},
+		{name: "both filters", config: map[string]interface{}{"name_regex": "a", "connection_status": "disconnected"}, want: []interface{}{"staging"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.config)
+			require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+			assert.Equal(t, tt.want, d.Get("cluster_names"))
+		})
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name_regex": "^prod-us$"})
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, map[string]interface{}{
+		"cluster_name":       "prod-us",
+		"description":        "",
+		"labels":             map[string]interface{}{"env": "prod"},
+		"connected":          true,
+		"last_heartbeat":     "2026-10-19T10:00:00Z",
+		"agent_version":      "2.11.1",
+		"kubernetes_version": "1.31",
+	}, d.Get("clusters.0"))
+}
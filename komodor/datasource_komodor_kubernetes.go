@@ -14,13 +14,19 @@ func dataSourceKomodorKubernetes() *schema.Resource {
 			"id": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The id and api key of the cluster integration",
+				Description: "The name of the cluster",
 			},
 			"cluster_name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The name of the cluster; must be unique to a Komodor account",
 			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key the Komodor agent in the cluster authenticates with",
+			},
 		},
 		Description: "Retrieves an existing Komodor Kubernetes cluster integration by name",
 	}
@@ -35,7 +41,10 @@ func dataSourceKomodorKubernetesRead(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("Could not get kubernetes cluster integration by name %s", err)
 	}
 
-	d.SetId(kubernetes.Id)
+	d.SetId(clusterName)
+	if err := d.Set("api_key", kubernetes.ApiKey); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
@@ -21,7 +21,8 @@ func TestAcc_datasource_komodor_kubernetes(t *testing.T) {
 				Config: testAccDatasourceKubernetesConfig(clusterName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceAddr, "cluster_name", clusterName),
-					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "id", clusterName),
+					resource.TestCheckResourceAttrPair(resourceAddr, "api_key", "komodor_kubernetes.test", "api_key"),
 				),
 			},
 		},
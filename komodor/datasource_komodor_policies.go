@@ -0,0 +1,160 @@
+package komodor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+)
+
+func dataSourceKomodorPolicies() *schema.Resource {
+	s := rbacListFilterSchema("Only return policies tagged with all of these key/value pairs.")
+	s["policies"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The matching policies, sorted by name.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tags": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"is_default": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the policy is attached to one of Komodor's default roles.",
+				},
+				"created_at": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"updated_at": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"statements": policyStatementsComputedSchema(),
+				"roles":      policyRolesComputedSchema(),
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorPoliciesRead,
+		Description: "Lists Komodor RBAC Policies, with their statements and the roles they are attached to.\n\n" +
+			"The API does not flag built-in policies, so a policy counts as `default` when it is attached to one of Komodor's default roles.",
+		Schema: s,
+	}
+}
+
+func policyRolesComputedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The roles the policy is attached to.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"is_default": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// rolesByPolicy indexes roles by the IDs of the policies attached to them.
+func rolesByPolicy(roles []Role) map[string][]Role {
+	index := make(map[string][]Role)
+	for _, role := range roles {
+		for _, p := range role.Policies {
+			index[p.Id] = append(index[p.Id], role)
+		}
+	}
+	return index
+}
+
+func flattenPolicyRoles(roles []Role) []interface{} {
+	return lo.Map(roles, func(r Role, _ int) interface{} {
+		return map[string]interface{}{
+			"id":         r.Id,
+			"name":       r.Name,
+			"is_default": r.IsDefault,
+		}
+	})
+}
+
+func isDefaultPolicy(roles []Role) bool {
+	return lo.ContainsBy(roles, func(r Role) bool { return r.IsDefault })
+}
+
+func dataSourceKomodorPoliciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	filter, err := expandRbacListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies, err := client.GetPolicies()
+	if err != nil {
+		return diag.Errorf("Error listing policies: %s", err)
+	}
+	roles, err := client.GetRoles()
+	if err != nil {
+		return diag.Errorf("Error listing roles: %s", err)
+	}
+	attachedRoles := rolesByPolicy(roles)
+
+	matching := lo.Filter(policies, func(p Policy, _ int) bool {
+		return filter.matches(p.Name, isDefaultPolicy(attachedRoles[p.Id]), p.TagMap())
+	})
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Name < matching[j].Name })
+
+	ids := lo.Map(matching, func(p Policy, _ int) string { return p.Id })
+	d.SetId(rbacListId(ids))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policies", lo.Map(matching, func(p Policy, _ int) interface{} {
+		return map[string]interface{}{
+			"id":         p.Id,
+			"name":       p.Name,
+			"type":       p.Type,
+			"tags":       p.TagMap(),
+			"is_default": isDefaultPolicy(attachedRoles[p.Id]),
+			"created_at": p.CreatedAt,
+			"updated_at": p.UpdatedAt,
+			"statements": flattenStatements(p.Statements),
+			"roles":      flattenPolicyRoles(attachedRoles[p.Id]),
+		}
+	})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_policies") }
+
+func TestAcc_datasource_komodor_policies(t *testing.T) {
+	roleName := testResourceName("ds-policies")
+	resourceAddr := "data.komodor_policies.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourcePoliciesConfig(roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "policies.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "ids.0", "komodor_rbac_bundle.test", "policy_id"),
+					resource.TestCheckResourceAttr(resourceAddr, "policies.0.is_default", "false"),
+					resource.TestCheckResourceAttr(resourceAddr, "policies.0.statements.0.actions.0", "view:all"),
+					resource.TestCheckResourceAttr(resourceAddr, "policies.0.statements.0.effect", "allow"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "policies.0.roles.0.id", "komodor_rbac_bundle.test", "role_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourcePoliciesConfig(roleName string) string {
+	return fmt.Sprintf(`
+resource "komodor_rbac_bundle" "test" {
+  role_name = %q
+
+  statements {
+    actions = ["view:all"]
+    resources_scope {
+      clusters = ["tf-acc-cluster"]
+    }
+  }
+}
+
+data "komodor_policies" "test" {
+  name_regex = "^${komodor_rbac_bundle.test.policy_name}$"
+  kind       = "custom"
+}
+`, roleName)
+}
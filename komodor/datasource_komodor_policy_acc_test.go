@@ -24,6 +24,13 @@ func TestAcc_datasource_komodor_policy_v2(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceAddr, "name", name),
 					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "statements_list.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "statements_list.0.actions.0", "view:all"),
+					resource.TestCheckResourceAttr(resourceAddr, "statements_list.0.effect", "allow"),
+					resource.TestCheckResourceAttr(resourceAddr, "statements_list.0.resources_scope.0.clusters.0", "tf-acc-cluster"),
+					resource.TestCheckResourceAttr(resourceAddr, "statements_list.0.resources_scope.0.namespaces.0", "default"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "statements"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "0"),
 				),
 			},
 		},
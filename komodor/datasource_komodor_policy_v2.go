@@ -11,7 +11,8 @@ import (
 func dataSourceKomodorPolicyV2() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceKomodorPolicyV2Read,
-		Description: "Retrieves an existing Komodor RBAC Policy by name",
+		Description: "Retrieves an existing Komodor RBAC Policy by name, with its statements and the roles it is attached to.\n\n" +
+			"`statements_list` has the same structure as the `statements` blocks of `komodor_policy_v2`, so a policy can be cloned or extended with `dynamic` blocks.",
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -32,11 +33,26 @@ func dataSourceKomodorPolicyV2() *schema.Resource {
 				Computed:    true,
 				Description: "The date and time of when the Policy was last updated",
 			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the policy",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The tags of the policy",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"statements": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The policy's statements",
 			},
+			"statements_list": policyStatementsComputedSchema(),
+			"roles":           policyRolesComputedSchema(),
 		},
 	}
 }
@@ -55,6 +71,11 @@ func dataSourceKomodorPolicyV2Read(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	roles, err := client.GetRoles()
+	if err != nil {
+		return diag.Errorf("Error listing roles: %s", err)
+	}
+
 	d.SetId(policy.Id)
 	if err := d.Set("name", policy.Name); err != nil {
 		return diag.FromErr(err)
@@ -65,9 +86,51 @@ func dataSourceKomodorPolicyV2Read(ctx context.Context, d *schema.ResourceData,
 	if err := d.Set("updated_at", policy.UpdatedAt); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("type", policy.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", policy.TagMap()); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := d.Set("statements", string(jsonStatements)); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("statements_list", flattenStatements(policy.Statements)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", flattenPolicyRoles(rolesByPolicy(roles)[policy.Id])); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
+
+// policyStatementsComputedSchema is the read-only counterpart of
+// policyStatementsSchema, filled with flattenStatements.
+func policyStatementsComputedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The policy statements, with the same structure as the `statements` blocks of `komodor_policy_v2`.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"actions": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"effect": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"resources_scope": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     resourcesScopeComputedResource(),
+				},
+			},
+		},
+	}
+}
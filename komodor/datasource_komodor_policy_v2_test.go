@@ -0,0 +1,50 @@
+package komodor
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceKomodorPolicyV2Statements(t *testing.T) {
+	statements := []Statement{
+		{
+			Actions: []string{"view:all"},
+			ResourcesScope: &ResourcesScope{
+				ClustersPatterns: []Pattern{{Include: "*"}},
+				Selectors:        []Selector{{Key: "team", Type: "label", Value: "a"}},
+			},
+		},
+		{
+			Actions:        []string{"view:all"},
+			Effect:         StatementEffectDeny,
+			ResourcesScope: &ResourcesScope{NamespacesPatterns: []Pattern{{Include: "secrets-*"}}},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceKomodorPolicyV2().Schema, map[string]interface{}{"name": "p"})
+	require.NoError(t, d.Set("statements_list", flattenStatements(statements)))
+
+	assert.Equal(t, 2, d.Get("statements_list.#"))
+	assert.Equal(t, "allow", d.Get("statements_list.0.effect"))
+	assert.Equal(t, "*", d.Get("statements_list.0.resources_scope.0.clusters_patterns.0.include"))
+	assert.Equal(t, "team", d.Get("statements_list.0.resources_scope.0.selectors.0.key"))
+	assert.Equal(t, "deny", d.Get("statements_list.1.effect"))
+	assert.Equal(t, StatementEffectDeny, expandStatements(d.Get("statements_list").([]interface{}))[1].Effect)
+}
+
+func TestRolesByPolicy(t *testing.T) {
+	roles := []Role{
+		{Id: "r1", Name: "admin", IsDefault: true, Policies: []PolicyRole{{Id: "p1"}, {Id: "p2"}}},
+		{Id: "r2", Name: "team-a", Policies: []PolicyRole{{Id: "p2"}}},
+	}
+
+	index := rolesByPolicy(roles)
+	assert.Len(t, index["p1"], 1)
+	assert.Len(t, index["p2"], 2)
+	assert.Empty(t, index["p3"])
+	assert.True(t, isDefaultPolicy(index["p2"]))
+	assert.False(t, isDefaultPolicy(index["p3"]))
+}
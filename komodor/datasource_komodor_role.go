@@ -35,6 +35,20 @@ func dataSourceKomodorRole() *schema.Resource {
 				Computed:    true,
 				Description: "Is default role",
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the role",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Key-value metadata attached to the role",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policies": rolePoliciesComputedSchema(),
 		},
 		Description: "Retrieves an existing Komodor Role by name",
 	}
@@ -63,6 +77,15 @@ func dataSourceKomodorRoleRead(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set("is_default", role.IsDefault); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("description", role.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata", role.Metadata); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policies", flattenRolePolicies(role.Policies)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
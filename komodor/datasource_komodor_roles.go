@@ -0,0 +1,144 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+)
+
+func dataSourceKomodorRoles() *schema.Resource {
+	s := rbacListFilterSchema("Only return roles whose `metadata` contains all of these key/value pairs.")
+	s["roles"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The matching roles, sorted by name.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"is_default": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+				"metadata": {
+					Type:     schema.TypeMap,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"created_at": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"updated_at": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"policies": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "The policies attached to the role, with their statements.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"statements": policyStatementsComputedSchema(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorRolesRead,
+		Description: "Lists Komodor RBAC Roles, with the policies attached to them and their statements",
+		Schema:      s,
+	}
+}
+
+func flattenListedRole(role Role, policies map[string]Policy) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          role.Id,
+		"name":        role.Name,
+		"description": role.Description,
+		"is_default":  role.IsDefault,
+		"metadata":    role.Metadata,
+		"created_at":  role.CreatedAt,
+		"updated_at":  role.UpdatedAt,
+		"policies": lo.Map(role.Policies, func(p PolicyRole, _ int) interface{} {
+			m := map[string]interface{}{
+				"id":   p.Id,
+				"name": p.Name,
+			}
+			if policy, ok := policies[p.Id]; ok {
+				m["statements"] = flattenStatements(policy.Statements)
+			}
+			return m
+		}),
+	}
+}
+
+// rbacListId derives a stable data source ID from the listed object IDs.
+func rbacListId(ids []string) string {
+	return fmt.Sprintf("%d", schema.HashString(strings.Join(ids, ",")))
+}
+
+func dataSourceKomodorRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	filter, err := expandRbacListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roles, err := client.GetRoles()
+	if err != nil {
+		return diag.Errorf("Error listing roles: %s", err)
+	}
+	policies, err := client.GetPolicies()
+	if err != nil {
+		return diag.Errorf("Error listing policies: %s", err)
+	}
+	policiesById := lo.KeyBy(policies, func(p Policy) string { return p.Id })
+
+	matching := lo.Filter(roles, func(r Role, _ int) bool {
+		return filter.matches(r.Name, r.IsDefault, r.Metadata)
+	})
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Name < matching[j].Name })
+
+	ids := lo.Map(matching, func(r Role, _ int) string { return r.Id })
+	d.SetId(rbacListId(ids))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", lo.Map(matching, func(r Role, _ int) interface{} {
+		return flattenListedRole(r, policiesById)
+	})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_roles") }
+
+func TestAcc_datasource_komodor_roles(t *testing.T) {
+	roleName := testResourceName("ds-roles")
+	resourceAddr := "data.komodor_roles.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceRolesConfig(roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.0.name", roleName),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.0.is_default", "false"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "ids.0", "komodor_rbac_bundle.test", "role_id"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "roles.0.policies.0.id", "komodor_rbac_bundle.test", "policy_id"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.0.policies.0.statements.0.actions.0", "view:all"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceRolesConfig(roleName string) string {
+	return fmt.Sprintf(`
+resource "komodor_rbac_bundle" "test" {
+  role_name = %q
+
+  statements {
+    actions = ["view:all"]
+    resources_scope {
+      clusters = ["tf-acc-cluster"]
+    }
+  }
+}
+
+data "komodor_roles" "test" {
+  name_regex = "^${komodor_rbac_bundle.test.role_name}$"
+  kind       = "custom"
+}
+`, roleName)
+}
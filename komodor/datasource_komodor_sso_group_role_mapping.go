@@ -0,0 +1,93 @@
+package komodor
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceKomodorSsoGroupRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorSsoGroupRoleMappingRead,
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the SSO group",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "IDs of the roles currently mapped to the group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"role_names": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Names of the roles currently mapped to the group",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration of the mapped roles. Empty when the mappings do not expire",
+			},
+			"role_expirations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of role ID to the expiration of that mapping. Roles mapped indefinitely are omitted",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Description: "Retrieves the roles mapped to an SSO group",
+	}
+}
+
+func dataSourceKomodorSsoGroupRoleMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	groupName := d.Get("group_name").(string)
+
+	group, statusCode, err := client.GetSsoGroup(groupName)
+	if err != nil {
+		if statusCode == 404 {
+			return diag.Errorf("SSO group %q not found", groupName)
+		}
+		return diag.Errorf("Could not get SSO group %s: %s", groupName, err)
+	}
+
+	roleIds, roleExpirations, earliest := flattenSsoGroupRoles(group.Roles, time.Now())
+	roleNames := make([]string, 0, len(group.Roles))
+	for _, role := range group.Roles {
+		if role.Name != "" && !roleGrantExpired(role.Expiration, time.Now()) {
+			roleNames = append(roleNames, role.Name)
+		}
+	}
+
+	d.SetId(groupName)
+	if err := d.Set("roles", roleIds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_names", roleNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration", earliest); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_expirations", roleExpirations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_sso_group_role_mapping") }
+
+func TestAcc_datasource_komodor_sso_group_role_mapping(t *testing.T) {
+	groupName := testResourceName("ds-sso-group")
+	roleName := testResourceName("ds-sso-role")
+	resourceAddr := "data.komodor_sso_group_role_mapping.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_sso_group_role_mapping" "test" {
+  group_name = %q
+  roles      = [komodor_role.test.id]
+}
+
+data "komodor_sso_group_role_mapping" "test" {
+  group_name = komodor_sso_group_role_mapping.test.group_name
+}
+`, roleName, groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "id", groupName),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "roles.*", "komodor_role.test", "id"),
+					resource.TestCheckTypeSetElemAttr(resourceAddr, "role_names.*", roleName),
+				),
+			},
+		},
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceKomodorUser() *schema.Resource {
@@ -17,12 +18,17 @@ func dataSourceKomodorUser() *schema.Resource {
 				Description: "The id of the user",
 			},
 			"display_name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The display name of the user",
+				Deprecated:  "display_name is not used to look up the user and will become read-only in a future release. Remove it from the configuration; the user's display name is still exported.",
 			},
 			"email": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The email of the user to look up",
 			},
 			"created_at": {
 				Type:        schema.TypeString,
@@ -42,9 +48,12 @@ func dataSourceKomodorUser() *schema.Resource {
 func dataSourceKomodorUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	email := d.Get("email").(string)
-	user, _, err := client.GetUser(email)
+	user, statusCode, err := client.GetUser(email)
 	if err != nil {
-		return diag.Errorf("Could not get user by email %s", email)
+		if statusCode == 404 {
+			return diag.Errorf("user %q not found", email)
+		}
+		return diag.Errorf("Could not get user by email %s: %s", email, err)
 	}
 	d.SetId(user.Id)
 	if err := d.Set("display_name", user.DisplayName); err != nil {
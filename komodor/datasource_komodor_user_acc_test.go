@@ -25,6 +25,14 @@ func TestAcc_datasource_komodor_user(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceAddr, "display_name"),
 				),
 			},
+			// Looking up by email alone exports the display name
+			{
+				Config: testAccDatasourceUserByEmailConfig(email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "email", email),
+					resource.TestCheckResourceAttr(resourceAddr, "display_name", "Acc Test DS User"),
+				),
+			},
 		},
 	})
 }
@@ -43,3 +51,16 @@ data "komodor_user" "test" {
 }
 `, email)
 }
+
+func testAccDatasourceUserByEmailConfig(email string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test DS User"
+}
+
+data "komodor_user" "test" {
+  email = komodor_user.test.email
+}
+`, email)
+}
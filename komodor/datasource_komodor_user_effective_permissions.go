@@ -0,0 +1,264 @@
+package komodor
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/samber/lo"
+)
+
+func dataSourceKomodorUserEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorUserEffectivePermissionsRead,
+		Description: "Lists what a Komodor User can do, by resolving the user's roles, the policies attached to them and their statements.\n\n" +
+			"Optionally evaluates whether the user may perform a given action on a cluster and namespace. " +
+			"The evaluation runs locally: actions are compared exactly, and `*_patterns` are matched as shell-style globs (`*`, `?`, `[...]`) where a value must match `include` and not match `exclude`.\n\n" +
+			"Precedence: a `deny` statement in any policy of any of the user's roles overrides every `allow`; otherwise the action is allowed when at least one `allow` statement matches, and denied by default. " +
+			"A `deny` statement only applies when its scope covers everything being checked, so denying a namespace does not deny the cluster as a whole.",
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The email (or ID) of the user",
+			},
+			"check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "An access question to evaluate against the user's permissions",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "The action to check, e.g. `view:all`",
+						},
+						"cluster": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The cluster to check. When omitted, any cluster matches",
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The namespace to check. When omitted, the check is made for the cluster as a whole",
+						},
+					},
+				},
+			},
+			"allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user may perform the action described in `check`: at least one policy allows it and none denies it. Always `false` when `check` is not set",
+			},
+			"allowed_by_policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the policies granting the action described in `check`",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"denied_by_policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the policies denying the action described in `check`",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "One entry per action allowed or denied to the user, with the scope it applies to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"effect": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resources_scope": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     resourcesScopeComputedResource(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// effectivePermission is a single action allowed or denied to a user through
+// a role and policy, together with the scope it applies to.
+type effectivePermission struct {
+	Action     string
+	Deny       bool
+	RoleId     string
+	RoleName   string
+	PolicyId   string
+	PolicyName string
+	Scope      *ResourcesScope
+}
+
+// resolveEffectivePermissions walks the user's non-expired roles and the
+// policies attached to them. Policies shared between roles are fetched once.
+func (c *Client) resolveEffectivePermissions(user *User) ([]effectivePermission, error) {
+	now := time.Now()
+	policies := make(map[string]*Policy)
+	permissions := make([]effectivePermission, 0)
+
+	for _, role := range user.Roles {
+		if roleGrantExpired(role.Expiration, now) {
+			continue
+		}
+		rolePolicies, _, err := c.GetRolePoliciesObject(role.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, rp := range rolePolicies {
+			policy, ok := policies[rp.Id]
+			if !ok {
+				if policy, _, err = c.GetPolicy(rp.Id); err != nil {
+					return nil, err
+				}
+				policies[rp.Id] = policy
+			}
+			for _, statement := range policy.Statements {
+				for _, action := range statement.Actions {
+					permissions = append(permissions, effectivePermission{
+						Action:     action,
+						Deny:       statement.IsDeny(),
+						RoleId:     role.Id,
+						RoleName:   role.Name,
+						PolicyId:   policy.Id,
+						PolicyName: policy.Name,
+						Scope:      statement.ResourcesScope,
+					})
+				}
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+// evaluatePermissions returns the IDs of the policies allowing and denying
+// action on the queried resource. The action is allowed when grantedBy is
+// non-empty and deniedBy is empty.
+func evaluatePermissions(permissions []effectivePermission, action string, q scope.Query) (grantedBy, deniedBy []string, err error) {
+	grantedBy = make([]string, 0)
+	deniedBy = make([]string, 0)
+	for _, p := range permissions {
+		if p.Action != action {
+			continue
+		}
+		if p.Deny {
+			ok, err := containsResourcesScope(p.Scope, q)
+			if err != nil {
+				return nil, nil, err
+			}
+			if ok {
+				deniedBy = append(deniedBy, p.PolicyId)
+			}
+			continue
+		}
+		ok, err := matchResourcesScope(p.Scope, q)
+		if err != nil {
+			return nil, nil, err
+		}
+		if ok {
+			grantedBy = append(grantedBy, p.PolicyId)
+		}
+	}
+	return lo.Uniq(grantedBy), lo.Uniq(deniedBy), nil
+}
+
+func flattenEffectivePermissions(permissions []effectivePermission) []interface{} {
+	return lo.Map(permissions, func(p effectivePermission, _ int) interface{} {
+		m := map[string]interface{}{
+			"action":      p.Action,
+			"effect":      lo.Ternary(p.Deny, StatementEffectDeny, StatementEffectAllow),
+			"role_id":     p.RoleId,
+			"role_name":   p.RoleName,
+			"policy_id":   p.PolicyId,
+			"policy_name": p.PolicyName,
+		}
+		if p.Scope != nil {
+			m["resources_scope"] = []interface{}{flattenResourcesScope(p.Scope)}
+		}
+		return m
+	})
+}
+
+func dataSourceKomodorUserEffectivePermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	email := d.Get("email").(string)
+
+	user, _, err := client.GetUser(email)
+	if err != nil {
+		return diag.Errorf("Could not get user by email %s: %s", email, err)
+	}
+
+	permissions, err := client.resolveEffectivePermissions(user)
+	if err != nil {
+		return diag.Errorf("Error resolving permissions of user %s: %s", email, err)
+	}
+
+	allowed := false
+	grantedBy := make([]string, 0)
+	deniedBy := make([]string, 0)
+	if checks := d.Get("check").([]interface{}); len(checks) > 0 && checks[0] != nil {
+		check := checks[0].(map[string]interface{})
+		grantedBy, deniedBy, err = evaluatePermissions(permissions, check["action"].(string), scope.Query{
+			Cluster:   check["cluster"].(string),
+			Namespace: check["namespace"].(string),
+		})
+		if err != nil {
+			return diag.Errorf("Error evaluating permissions of user %s: %s", email, err)
+		}
+		allowed = len(grantedBy) > 0 && len(deniedBy) == 0
+	}
+
+	d.SetId(user.Id)
+	if err := d.Set("permissions", flattenEffectivePermissions(permissions)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("allowed", allowed); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("allowed_by_policies", grantedBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("denied_by_policies", deniedBy); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_user_effective_permissions") }
+
+func TestAcc_datasource_komodor_user_effective_permissions(t *testing.T) {
+	roleName := testResourceName("ds-perms-role")
+	userEmail := accTestPrefix + "ds-perms-user@komodor-test.com"
+	resourceAddr := "data.komodor_user_effective_permissions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceUserEffectivePermissionsConfig(roleName, userEmail),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "permissions.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "permissions.0.action", "view:all"),
+					resource.TestCheckResourceAttr(resourceAddr, "allowed", "true"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "allowed_by_policies.0", "komodor_rbac_bundle.test", "policy_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceUserEffectivePermissionsConfig(roleName, userEmail string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Permissions User"
+}
+
+resource "komodor_rbac_bundle" "test" {
+  role_name = %q
+  members   = [komodor_user.test.email]
+
+  statements {
+    actions = ["view:all"]
+    resources_scope {
+      clusters_patterns {
+        include = "prod-*"
+        exclude = ""
+      }
+    }
+  }
+}
+
+data "komodor_user_effective_permissions" "test" {
+  email = komodor_user.test.email
+
+  check {
+    action    = "view:all"
+    cluster   = "prod-us"
+    namespace = "default"
+  }
+
+  depends_on = [komodor_rbac_bundle.test]
+}
+`, userEmail, roleName)
+}
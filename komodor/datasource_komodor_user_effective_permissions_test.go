@@ -0,0 +1,41 @@
+package komodor
+
+import (
+	"testing"
+
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluatePermissions(t *testing.T) {
+	permissions := []effectivePermission{
+		{Action: "view:all", PolicyId: "p-view", Scope: &ResourcesScope{ClustersPatterns: []Pattern{{Include: "*"}}}},
+		{Action: "edit:deployments", PolicyId: "p-edit", Scope: &ResourcesScope{Clusters: []string{"staging"}, Namespaces: []string{"payments"}}},
+		{Action: "edit:deployments", PolicyId: "p-edit", Scope: &ResourcesScope{Clusters: []string{"staging"}, Namespaces: []string{"billing"}}},
+		{Action: "view:all", PolicyId: "p-secrets", Deny: true, Scope: &ResourcesScope{NamespacesPatterns: []Pattern{{Include: "secrets-*"}}}},
+	}
+
+	tests := []struct {
+		name       string
+		action     string
+		query      scope.Query
+		want       []string
+		wantDenied []string
+	}{
+		{name: "granted everywhere", action: "view:all", query: scope.Query{Cluster: "prod", Namespace: "kube-system"}, want: []string{"p-view"}, wantDenied: []string{}},
+		{name: "granted on namespace", action: "edit:deployments", query: scope.Query{Cluster: "staging", Namespace: "billing"}, want: []string{"p-edit"}, wantDenied: []string{}},
+		{name: "not granted on other cluster", action: "edit:deployments", query: scope.Query{Cluster: "prod", Namespace: "billing"}, want: []string{}, wantDenied: []string{}},
+		{name: "unknown action", action: "manage:users", query: scope.Query{Cluster: "prod"}, want: []string{}, wantDenied: []string{}},
+		{name: "explicitly denied namespace", action: "view:all", query: scope.Query{Cluster: "prod", Namespace: "secrets-db"}, want: []string{"p-view"}, wantDenied: []string{"p-secrets"}},
+		{name: "denying a namespace does not deny the cluster", action: "view:all", query: scope.Query{Cluster: "prod"}, want: []string{"p-view"}, wantDenied: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, denied, err := evaluatePermissions(permissions, tc.action, tc.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantDenied, denied)
+		})
+	}
+}
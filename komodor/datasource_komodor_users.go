@@ -0,0 +1,196 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func dataSourceKomodorUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorUsersRead,
+		Description: "Lists Komodor Users, optionally filtered by email domain, role and creation time",
+		Schema: map[string]*schema.Schema{
+			"email_domain": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Only return users whose email address is in this domain, e.g. `example.com`. Matching is case-insensitive.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Only return users bound to this role, given by ID or name.",
+			},
+			"created_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return users created after this RFC3339 timestamp.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching users, in the same order as `users`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching users, sorted by email.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": userRolesComputedSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func userRolesComputedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The roles the user is bound to.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"expiration": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "When the role grant expires. Empty when it does not expire.",
+				},
+			},
+		},
+	}
+}
+
+func flattenUserRoles(roles []UserRoleResponse) []interface{} {
+	return lo.Map(roles, func(r UserRoleResponse, _ int) interface{} {
+		return map[string]interface{}{
+			"id":         r.Id,
+			"name":       r.Name,
+			"expiration": r.Expiration,
+		}
+	})
+}
+
+type usersListFilter struct {
+	EmailDomain  string
+	Role         string
+	CreatedAfter *time.Time
+}
+
+func (f usersListFilter) matches(u User) bool {
+	if f.EmailDomain != "" {
+		at := strings.LastIndex(u.Email, "@")
+		if at < 0 || !strings.EqualFold(u.Email[at+1:], strings.TrimPrefix(f.EmailDomain, "@")) {
+			return false
+		}
+	}
+	if f.Role != "" && !lo.ContainsBy(u.Roles, func(r UserRoleResponse) bool { return r.Id == f.Role || r.Name == f.Role }) {
+		return false
+	}
+	if f.CreatedAfter != nil {
+		createdAt, err := time.Parse(time.RFC3339, u.CreatedAt)
+		if err != nil {
+			log.Printf("[WARN] Could not parse creation time %q of user %s: %s", u.CreatedAt, u.Id, err)
+			return false
+		}
+		if !createdAt.After(*f.CreatedAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+func expandUsersListFilter(d *schema.ResourceData) (usersListFilter, error) {
+	filter := usersListFilter{
+		EmailDomain: d.Get("email_domain").(string),
+		Role:        d.Get("role").(string),
+	}
+	if v := d.Get("created_after").(string); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid created_after %q: %w", v, err)
+		}
+		filter.CreatedAfter = &t
+	}
+	return filter, nil
+}
+
+func dataSourceKomodorUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	filter, err := expandUsersListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	users, err := client.GetUsers()
+	if err != nil {
+		return diag.Errorf("Error listing users: %s", err)
+	}
+
+	matching := lo.Filter(users, func(u User, _ int) bool { return filter.matches(u) })
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Email < matching[j].Email })
+
+	ids := lo.Map(matching, func(u User, _ int) string { return u.Id })
+	d.SetId(rbacListId(ids))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("users", lo.Map(matching, func(u User, _ int) interface{} {
+		return map[string]interface{}{
+			"id":           u.Id,
+			"email":        u.Email,
+			"display_name": u.DisplayName,
+			"created_at":   u.CreatedAt,
+			"updated_at":   u.UpdatedAt,
+			"roles":        flattenUserRoles(u.Roles),
+		}
+	})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_users") }
+
+func TestAcc_datasource_komodor_users(t *testing.T) {
+	email := accTestPrefix + "ds-users@komodor-test.com"
+	roleName := testResourceName("ds-users-role")
+	resourceAddr := "data.komodor_users.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceUsersConfig(email, roleName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "users.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "users.0.email", email),
+					resource.TestCheckResourceAttrPair(resourceAddr, "ids.0", "komodor_user.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "users.0.roles.0.id", "komodor_role.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceUsersConfig(email, roleName string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test DS Users"
+}
+
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_user_role_binding" "test" {
+  name    = komodor_role.test.name
+  user_id = komodor_user.test.id
+  roles   = [komodor_role.test.id]
+}
+
+data "komodor_users" "test" {
+  email_domain  = "komodor-test.com"
+  role          = komodor_role.test.name
+  created_after = "2020-01-01T00:00:00Z"
+  depends_on    = [komodor_user_role_binding.test]
+}
+`, email, roleName)
+}
@@ -0,0 +1,43 @@
+package komodor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersListFilterMatches(t *testing.T) {
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := User{
+		Id:        "u-1",
+		Email:     "alice@Example.com",
+		CreatedAt: "2026-02-01T10:00:00Z",
+		Roles:     []UserRoleResponse{{Id: "role-1", Name: "viewer"}},
+	}
+
+	tests := []struct {
+		name   string
+		filter usersListFilter
+		user   User
+		want   bool
+	}{
+		{name: "no filters", filter: usersListFilter{}, user: user, want: true},
+		{name: "domain match is case-insensitive", filter: usersListFilter{EmailDomain: "example.com"}, user: user, want: true},
+		{name: "domain with leading at", filter: usersListFilter{EmailDomain: "@example.com"}, user: user, want: true},
+		{name: "domain mismatch", filter: usersListFilter{EmailDomain: "other.com"}, user: user, want: false},
+		{name: "subdomain does not match", filter: usersListFilter{EmailDomain: "example.com"}, user: User{Email: "bob@eu.example.com"}, want: false},
+		{name: "role by id", filter: usersListFilter{Role: "role-1"}, user: user, want: true},
+		{name: "role by name", filter: usersListFilter{Role: "viewer"}, user: user, want: true},
+		{name: "role mismatch", filter: usersListFilter{Role: "admin"}, user: user, want: false},
+		{name: "created after cutoff", filter: usersListFilter{CreatedAfter: &cutoff}, user: user, want: true},
+		{name: "created before cutoff", filter: usersListFilter{CreatedAfter: &cutoff}, user: User{CreatedAt: "2025-06-01T00:00:00Z"}, want: false},
+		{name: "unparseable creation time", filter: usersListFilter{CreatedAfter: &cutoff}, user: User{CreatedAt: "yesterday"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.matches(tt.user))
+		})
+	}
+}
@@ -22,6 +22,8 @@ func TestAcc_datasource_komodor_workspace(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceAddr, "name", name),
 					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
+					resource.TestCheckResourceAttrPair("data.komodor_workspace.by_name", "id", "komodor_workspace.test", "id"),
+					resource.TestCheckResourceAttr("data.komodor_workspace.by_name", "scopes.0.namespaces.0", "default"),
 				),
 			},
 		},
@@ -42,5 +44,10 @@ data "komodor_workspace" "test" {
   id         = komodor_workspace.test.id
   depends_on = [komodor_workspace.test]
 }
+
+data "komodor_workspace" "by_name" {
+  name       = komodor_workspace.test.name
+  depends_on = [komodor_workspace.test]
+}
 `, name)
 }
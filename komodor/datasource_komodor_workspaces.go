@@ -0,0 +1,150 @@
+package komodor
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func dataSourceKomodorWorkspaces() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKomodorWorkspacesRead,
+		Description: "Lists Komodor workspaces, optionally filtered by name",
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "A regular expression the name must match. Unanchored unless `^` and `$` are used.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching workspaces, in the same order as `workspaces`.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"workspaces": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching workspaces, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scopes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     resourcesScopeComputedResource(),
+						},
+						"cluster_group": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     workspaceClusterGroupComputedResource(),
+						},
+						"label_selector": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     workspaceLabelSelectorComputedResource(),
+						},
+						"author_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_updated_by_email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenListedWorkspace(w Workspace) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          w.Id,
+		"name":        w.Name,
+		"description": w.Description,
+		"kind":        w.EffectiveKind(),
+		"scopes": lo.Map(w.Scopes, func(scope ResourcesScope, _ int) interface{} {
+			return flattenResourcesScope(&scope)
+		}),
+		"cluster_group":         flattenWorkspaceClusterGroup(w.ClusterGroup),
+		"label_selector":        flattenWorkspaceLabelSelector(w.LabelSelector),
+		"author_email":          w.AuthorEmail,
+		"last_updated_by_email": w.LastUpdatedByEmail,
+		"created_at":            w.CreatedAt,
+		"updated_at":            w.LastUpdated,
+	}
+}
+
+func dataSourceKomodorWorkspacesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	var nameRegex *regexp.Regexp
+	if expr := d.Get("name_regex").(string); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nameRegex = re
+	}
+
+	workspaces, err := client.GetWorkspaces()
+	if err != nil {
+		return diag.Errorf("Error listing workspaces: %s", err)
+	}
+
+	matching := lo.Filter(workspaces, func(w Workspace, _ int) bool {
+		return nameRegex == nil || nameRegex.MatchString(w.Name)
+	})
+	sort.SliceStable(matching, func(i, j int) bool {
+		if matching[i].Name != matching[j].Name {
+			return matching[i].Name < matching[j].Name
+		}
+		return matching[i].Id < matching[j].Id
+	})
+
+	ids := lo.Map(matching, func(w Workspace, _ int) string { return w.Id })
+	d.SetId(rbacListId(ids))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("workspaces", lo.Map(matching, func(w Workspace, _ int) interface{} {
+		return flattenListedWorkspace(w)
+	})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
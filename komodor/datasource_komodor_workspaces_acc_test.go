@@ -0,0 +1,49 @@
+package komodor
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() { registerAccTest("datasource_komodor_workspaces") }
+
+func TestAcc_datasource_komodor_workspaces(t *testing.T) {
+	name := testResourceName("ds-workspaces")
+	resourceAddr := "data.komodor_workspaces.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatasourceWorkspacesConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "workspaces.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "ids.0", "komodor_workspace.test", "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "workspaces.0.name", name),
+					resource.TestCheckResourceAttr(resourceAddr, "workspaces.0.scopes.0.clusters.0", "tf-acc-cluster-1"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "workspaces.0.author_email"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatasourceWorkspacesConfig(name string) string {
+	return fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+  scopes {
+    clusters = ["tf-acc-cluster-1"]
+  }
+}
+
+data "komodor_workspaces" "test" {
+  name_regex = "^%s$"
+  depends_on = [komodor_workspace.test]
+}
+`, name, regexp.QuoteMeta(name))
+}
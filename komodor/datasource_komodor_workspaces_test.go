@@ -0,0 +1,70 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWorkspacesListTestClient(t *testing.T) *Client {
+	t.Helper()
+	workspaces := []Workspace{
+		{Id: "ws-3", Name: "team-b", Scopes: []ResourcesScope{{Namespaces: []string{"team-b"}}}, AuthorEmail: "b@example.com"},
+		{Id: "ws-1", Name: "team-a", Scopes: []ResourcesScope{{Namespaces: []string{"team-a"}}}, LastUpdatedByEmail: "a@example.com"},
+		{Id: "ws-2", Name: "platform", Kind: WorkspaceKindClusterGroup, ClusterGroup: &WorkspaceClusterGroup{Clusters: []string{"prod"}}},
+		{Id: "ws-4", Name: "team-b"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/workspaces" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(workspaces)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient("key", server.URL)
+}
+
+func TestDataSourceWorkspaceByName(t *testing.T) {
+	client := newWorkspacesListTestClient(t)
+	r := dataSourceKomodorWorkspace()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "team-a"})
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, "ws-1", d.Id())
+	assert.Equal(t, "a@example.com", d.Get("last_updated_by_email"))
+	assert.Equal(t, "team-a", d.Get("scopes.0.namespaces.0"))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "team-b"})
+	diags := r.ReadContext(context.Background(), d, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `2 workspaces are named "team-b" (ws-3, ws-4)`)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "team-z"})
+	diags = r.ReadContext(context.Background(), d, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `no workspace named "team-z"`)
+}
+
+func TestDataSourceWorkspacesList(t *testing.T) {
+	client := newWorkspacesListTestClient(t)
+	r := dataSourceKomodorWorkspaces()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, []interface{}{"ws-2", "ws-1", "ws-3", "ws-4"}, d.Get("ids"))
+	assert.Equal(t, "cluster_group", d.Get("workspaces.0.kind"))
+	assert.Equal(t, "prod", d.Get("workspaces.0.cluster_group.0.clusters.0"))
+	assert.Equal(t, "scopes", d.Get("workspaces.1.kind"))
+	assert.Equal(t, "b@example.com", d.Get("workspaces.2.author_email"))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name_regex": "^team-"})
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, []interface{}{"ws-1", "ws-3", "ws-4"}, d.Get("ids"))
+}
@@ -0,0 +1,352 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+)
+
+var _ function.Function = (*scopeMatchesFunction)(nil)
+
+type scopeMatchesFunction struct{}
+
+func NewScopeMatchesFunction() function.Function {
+	return &scopeMatchesFunction{}
+}
+
+func (f *scopeMatchesFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scope_matches"
+}
+
+func (f *scopeMatchesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a resource falls within a Komodor resource scope",
+		MarkdownDescription: "Evaluates a resource against a scope the same way Komodor does, without calling the API. " +
+			"The scope can be a `resources_scope` of a `komodor_policy_v2` statement, a `scopes` entry of a `komodor_workspace`, " +
+			"or a `scope` of a `komodor_cost_right_sizing_policy`, passed as-is or written as an object literal with the same attributes.\n\n" +
+			"Exact names are compared literally. `*_patterns` are shell-style globs (`*`, `?`, `[...]`): a value matches when it matches " +
+			"`include` and does not match `exclude`. A dimension without names or patterns is unrestricted, and every dimension, " +
+			"selector and selector pattern must match. Attributes left out of `resource` are not evaluated.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "scope",
+				Description: "The scope, with any of the attributes `clusters`, `clusters_patterns`, `namespaces`, `namespaces_patterns`, `workload_names`, `workload_names_patterns`, `resource_types`, `resource_types_patterns`, `selectors` and `selectors_patterns`.",
+			},
+			function.DynamicParameter{
+				Name:        "resource",
+				Description: "The resource to evaluate, with any of the attributes `cluster`, `namespace`, `workload`, `resource_type`, `labels` and `annotations`.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *scopeMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var scopeArg, resourceArg types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &scopeArg, &resourceArg)
+	if resp.Error != nil {
+		return
+	}
+
+	rawScope, err := dynamicToGo(ctx, scopeArg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	s, err := parseScopeArgument(rawScope)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	rawResource, err := dynamicToGo(ctx, resourceArg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	q, err := parseQueryArgument(rawResource)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	matches, err := s.Matches(q)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.BoolValue(matches))
+}
+
+func dynamicToGo(ctx context.Context, v types.Dynamic) (interface{}, error) {
+	tfValue, err := v.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tftypesToGo(tfValue)
+}
+
+// tftypesToGo converts a Terraform value into the plain maps, slices and
+// strings the argument parsers work with.
+func tftypesToGo(v tftypes.Value) (interface{}, error) {
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("value must be known")
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	t := v.Type()
+	switch {
+	case t.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case t.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case t.Is(tftypes.Object{}), t.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(attrs))
+		for k, attr := range attrs {
+			converted, err := tftypesToGo(attr)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}), t.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, 0, len(elems))
+		for i, elem := range elems {
+			converted, err := tftypesToGo(elem)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list = append(list, converted)
+		}
+		return list, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value of type %s", t)
+}
+
+var scopeArgumentDimensions = map[string]string{
+	"clusters":       "clusters_patterns",
+	"namespaces":     "namespaces_patterns",
+	"workload_names": "workload_names_patterns",
+	"resource_types": "resource_types_patterns",
+}
+
+func parseScopeArgument(raw interface{}) (scope.Scope, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return scope.Scope{}, fmt.Errorf("scope must be an object, got %T", raw)
+	}
+	if err := checkArgumentKeys(m, "clusters", "clusters_patterns", "namespaces", "namespaces_patterns",
+		"workload_names", "workload_names_patterns", "resource_types", "resource_types_patterns",
+		"selectors", "selectors_patterns"); err != nil {
+		return scope.Scope{}, fmt.Errorf("scope: %w", err)
+	}
+
+	dims := make(map[string]scope.Dimension, len(scopeArgumentDimensions))
+	for namesKey, patternsKey := range scopeArgumentDimensions {
+		names, err := parseStringList(m[namesKey])
+		if err != nil {
+			return scope.Scope{}, fmt.Errorf("scope.%s: %w", namesKey, err)
+		}
+		patterns, err := parsePatternList(m[patternsKey])
+		if err != nil {
+			return scope.Scope{}, fmt.Errorf("scope.%s: %w", patternsKey, err)
+		}
+		dims[namesKey] = scope.Dimension{Names: names, Patterns: patterns}
+	}
+
+	s := scope.Scope{
+		Clusters:      dims["clusters"],
+		Namespaces:    dims["namespaces"],
+		Workloads:     dims["workload_names"],
+		ResourceTypes: dims["resource_types"],
+	}
+
+	selectors, err := parseObjectList(m["selectors"])
+	if err != nil {
+		return scope.Scope{}, fmt.Errorf("scope.selectors: %w", err)
+	}
+	for i, sel := range selectors {
+		value, _ := sel["value"].(string)
+		key, _ := sel["key"].(string)
+		selectorType, _ := sel["type"].(string)
+		if key == "" {
+			return scope.Scope{}, fmt.Errorf("scope.selectors[%d]: key is required", i)
+		}
+		s.Selectors = append(s.Selectors, scope.Selector{Key: key, Type: selectorType, Value: value})
+	}
+
+	selectorPatterns, err := parseObjectList(m["selectors_patterns"])
+	if err != nil {
+		return scope.Scope{}, fmt.Errorf("scope.selectors_patterns: %w", err)
+	}
+	for i, sp := range selectorPatterns {
+		key, _ := sp["key"].(string)
+		selectorType, _ := sp["type"].(string)
+		if key == "" {
+			return scope.Scope{}, fmt.Errorf("scope.selectors_patterns[%d]: key is required", i)
+		}
+		values, err := parsePatternList(sp["value"])
+		if err != nil {
+			return scope.Scope{}, fmt.Errorf("scope.selectors_patterns[%d].value: %w", i, err)
+		}
+		for _, v := range values {
+			s.SelectorPatterns = append(s.SelectorPatterns, scope.SelectorPattern{Key: key, Type: selectorType, Value: v})
+		}
+	}
+
+	return s, nil
+}
+
+func parseQueryArgument(raw interface{}) (scope.Query, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return scope.Query{}, fmt.Errorf("resource must be an object, got %T", raw)
+	}
+	if err := checkArgumentKeys(m, "cluster", "namespace", "workload", "resource_type", "labels", "annotations"); err != nil {
+		return scope.Query{}, fmt.Errorf("resource: %w", err)
+	}
+
+	q := scope.Query{}
+	for key, target := range map[string]*string{
+		"cluster":       &q.Cluster,
+		"namespace":     &q.Namespace,
+		"workload":      &q.Workload,
+		"resource_type": &q.ResourceType,
+	} {
+		if m[key] == nil {
+			continue
+		}
+		s, ok := m[key].(string)
+		if !ok {
+			return scope.Query{}, fmt.Errorf("resource.%s must be a string", key)
+		}
+		*target = s
+	}
+
+	var err error
+	if q.Labels, err = parseStringMap(m["labels"]); err != nil {
+		return scope.Query{}, fmt.Errorf("resource.labels: %w", err)
+	}
+	if q.Annotations, err = parseStringMap(m["annotations"]); err != nil {
+		return scope.Query{}, fmt.Errorf("resource.annotations: %w", err)
+	}
+
+	return q, nil
+}
+
+func checkArgumentKeys(m map[string]interface{}, allowed ...string) error {
+	known := make(map[string]bool, len(allowed))
+	for _, k := range allowed {
+		known[k] = true
+	}
+	var unknown []string
+	for k := range m {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unsupported attributes %s; supported attributes are %s", formatQuotedStringList(unknown), formatQuotedStringList(allowed))
+	}
+	return nil
+}
+
+func parseStringList(raw interface{}) ([]string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a list of strings")
+	}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a list of strings")
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func parseStringMap(raw interface{}) (map[string]string, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a map of strings")
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("must be a map of strings")
+		}
+		result[k] = s
+	}
+	return result, nil
+}
+
+// parseObjectList accepts a list of objects, or a single object as written
+// for single-item pattern blocks.
+func parseObjectList(raw interface{}) ([]map[string]interface{}, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []interface{}:
+		result := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("must be a list of objects")
+			}
+			result = append(result, m)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("must be an object or a list of objects")
+}
+
+func parsePatternList(raw interface{}) ([]scope.Pattern, error) {
+	objects, err := parseObjectList(raw)
+	if err != nil {
+		return nil, err
+	}
+	patterns := make([]scope.Pattern, 0, len(objects))
+	for _, o := range objects {
+		if err := checkArgumentKeys(o, "include", "exclude"); err != nil {
+			return nil, err
+		}
+		include, _ := o["include"].(string)
+		exclude, _ := o["exclude"].(string)
+		patterns = append(patterns, scope.Pattern{Include: include, Exclude: exclude})
+	}
+	return patterns, nil
+}
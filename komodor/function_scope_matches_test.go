@@ -0,0 +1,125 @@
+package komodor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopeArgument(t *testing.T) {
+	raw := map[string]interface{}{
+		"clusters": []interface{}{"prod"},
+		"namespaces_patterns": []interface{}{
+			map[string]interface{}{"include": "team-*", "exclude": "team-legacy"},
+		},
+		"workload_names_patterns": map[string]interface{}{"include": "api-*"},
+		"selectors": []interface{}{
+			map[string]interface{}{"key": "app", "type": "label", "value": "web"},
+		},
+		"selectors_patterns": []interface{}{
+			map[string]interface{}{"key": "tier", "type": "label", "value": map[string]interface{}{"include": "front*"}},
+		},
+	}
+
+	got, err := parseScopeArgument(raw)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, got.Clusters.Names)
+	assert.Equal(t, []scope.Pattern{{Include: "team-*", Exclude: "team-legacy"}}, got.Namespaces.Patterns)
+	assert.Equal(t, []scope.Pattern{{Include: "api-*"}}, got.Workloads.Patterns)
+	assert.True(t, got.ResourceTypes.IsEmpty())
+	assert.Equal(t, []scope.Selector{{Key: "app", Type: "label", Value: "web"}}, got.Selectors)
+	assert.Equal(t, []scope.SelectorPattern{{Key: "tier", Type: "label", Value: scope.Pattern{Include: "front*"}}}, got.SelectorPatterns)
+}
+
+func TestParseScopeArgumentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+	}{
+		{name: "not an object", raw: "prod"},
+		{name: "unknown attribute", raw: map[string]interface{}{"cluster": []interface{}{"prod"}}},
+		{name: "names are not strings", raw: map[string]interface{}{"clusters": []interface{}{true}}},
+		{name: "unknown pattern attribute", raw: map[string]interface{}{"clusters_patterns": map[string]interface{}{"match": "*"}}},
+		{name: "selector without key", raw: map[string]interface{}{"selectors": []interface{}{map[string]interface{}{"value": "web"}}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseScopeArgument(tc.raw)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseQueryArgument(t *testing.T) {
+	got, err := parseQueryArgument(map[string]interface{}{
+		"cluster":   "prod",
+		"namespace": "payments",
+		"labels":    map[string]interface{}{"app": "web"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, scope.Query{Cluster: "prod", Namespace: "payments", Labels: map[string]string{"app": "web"}}, got)
+
+	_, err = parseQueryArgument(map[string]interface{}{"clusters": "prod"})
+	assert.Error(t, err)
+}
+
+func TestScopeMatchesFunctionRun(t *testing.T) {
+	ctx := context.Background()
+	patternType := types.ObjectType{AttrTypes: map[string]attr.Type{"include": types.StringType, "exclude": types.StringType}}
+
+	scopeArg := types.ObjectValueMust(
+		map[string]attr.Type{
+			"clusters":            types.ListType{ElemType: types.StringType},
+			"namespaces_patterns": types.ListType{ElemType: patternType},
+		},
+		map[string]attr.Value{
+			"clusters": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("prod")}),
+			"namespaces_patterns": types.ListValueMust(patternType, []attr.Value{
+				types.ObjectValueMust(patternType.AttrTypes, map[string]attr.Value{
+					"include": types.StringValue("team-*"),
+					"exclude": types.StringValue("team-legacy"),
+				}),
+			}),
+		},
+	)
+
+	resourceArg := func(cluster, namespace string) types.Object {
+		return types.ObjectValueMust(
+			map[string]attr.Type{"cluster": types.StringType, "namespace": types.StringType},
+			map[string]attr.Value{"cluster": types.StringValue(cluster), "namespace": types.StringValue(namespace)},
+		)
+	}
+
+	tests := []struct {
+		name     string
+		resource types.Object
+		want     bool
+	}{
+		{name: "in scope", resource: resourceArg("prod", "team-a"), want: true},
+		{name: "excluded namespace", resource: resourceArg("prod", "team-legacy"), want: false},
+		{name: "other cluster", resource: resourceArg("staging", "team-a"), want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.DynamicValue(scopeArg),
+					types.DynamicValue(tc.resource),
+				}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.BoolUnknown())}
+
+			NewScopeMatchesFunction().Run(ctx, req, &resp)
+			require.Nil(t, resp.Error)
+			assert.Equal(t, types.BoolValue(tc.want), resp.Result.Value())
+		})
+	}
+}
@@ -7,7 +7,59 @@ import (
 )
 
 type Kubernetes struct {
-	Id string `json:"apiKey"`
+	ApiKey      string `json:"apiKey"`
+	ClusterName string `json:"clusterName"`
+	// PreviousApiKey is set after a rotation, until the grace period in
+	// which the old key still authenticates ends.
+	PreviousApiKey          string `json:"previousApiKey,omitempty"`
+	PreviousApiKeyExpiresAt string `json:"previousApiKeyExpiresAt,omitempty"`
+	KubernetesSettings
+	KubernetesStatus
+}
+
+// KubernetesStatus is what the Komodor agent last reported about a cluster.
+// It is empty until the agent connects for the first time.
+type KubernetesStatus struct {
+	Connected         bool   `json:"isConnected"`
+	LastHeartbeat     string `json:"lastHeartbeat"`
+	AgentVersion      string `json:"agentVersion"`
+	KubernetesVersion string `json:"k8sVersion"`
+}
+
+// KubernetesSettings are the settings of a cluster integration that can be
+// changed without recreating it, and so without rotating its API key.
+type KubernetesSettings struct {
+	Description string                   `json:"description"`
+	Labels      map[string]string        `json:"labels"`
+	Features    *KubernetesAgentFeatures `json:"features,omitempty"`
+}
+
+// KubernetesAgentFeatures are the Komodor agent features enabled in a cluster.
+type KubernetesAgentFeatures struct {
+	Metrics       bool `json:"metrics"`
+	NetworkMapper bool `json:"networkMapper"`
+	Actions       bool `json:"actions"`
+	LogsRedaction bool `json:"logsRedaction"`
+}
+
+type newKubernetes struct {
+	ClusterName string `json:"clusterName"`
+	KubernetesSettings
+}
+
+func (c *Client) GetKubernetesClusters() ([]Kubernetes, error) {
+	res, _, err := c.executeHttpRequest(http.MethodGet, c.GetIntegrationsUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []Kubernetes
+	err = json.Unmarshal(res, &clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
 }
 
 func (c *Client) GetKubernetesCluster(clusterName string) (*Kubernetes, int, error) {
@@ -27,8 +79,8 @@ func (c *Client) GetKubernetesCluster(clusterName string) (*Kubernetes, int, err
 	return &kubernetes, statusCode, nil
 }
 
-func (c *Client) CreateKubernetesCluster(name string) (*Kubernetes, error) {
-	jsonPolicy, err := json.Marshal(map[string]string{"clusterName": name})
+func (c *Client) CreateKubernetesCluster(name string, settings *KubernetesSettings) (*Kubernetes, error) {
+	jsonPolicy, err := json.Marshal(newKubernetes{ClusterName: name, KubernetesSettings: *settings})
 
 	if err != nil {
 		return nil, err
@@ -48,6 +100,46 @@ func (c *Client) CreateKubernetesCluster(name string) (*Kubernetes, error) {
 	return &kubernetes, nil
 }
 
+func (c *Client) UpdateKubernetesCluster(clusterName string, settings *KubernetesSettings) (*Kubernetes, error) {
+	jsonSettings, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := c.executeHttpRequest(http.MethodPut, fmt.Sprintf("%s/%s", c.GetIntegrationsUrl(), clusterName), &jsonSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	var kubernetes Kubernetes
+	err = json.Unmarshal(res, &kubernetes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetes, nil
+}
+
+// RotateKubernetesApiKey issues a new API key for a cluster integration. The
+// old key keeps working for gracePeriodHours.
+func (c *Client) RotateKubernetesApiKey(clusterName string, gracePeriodHours int) (*Kubernetes, error) {
+	jsonRotation, err := json.Marshal(map[string]int{"gracePeriodHours": gracePeriodHours})
+	if err != nil {
+		return nil, err
+	}
+	res, _, err := c.executeHttpRequest(http.MethodPost, fmt.Sprintf("%s/%s/rotate-key", c.GetIntegrationsUrl(), clusterName), &jsonRotation)
+	if err != nil {
+		return nil, err
+	}
+
+	var kubernetes Kubernetes
+	err = json.Unmarshal(res, &kubernetes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetes, nil
+}
+
 func (c *Client) DeleteKubernetesCluster(id string) error {
 	_, _, err := c.executeHttpRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.GetIntegrationsUrl(), id), nil)
 	if err != nil {
@@ -0,0 +1,44 @@
+package komodor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	komodorAgentHelmRepoName  = "komodorio"
+	komodorAgentHelmRepoUrl   = "https://helm-charts.komodor.io"
+	komodorAgentHelmChart     = "komodorio/komodor-agent"
+	komodorAgentHelmRelease   = "komodor-agent"
+	komodorAgentHelmNamespace = "komodor"
+)
+
+// komodorAgentHelmValues renders the values the Komodor agent Helm chart
+// needs to report a cluster, as YAML.
+func komodorAgentHelmValues(clusterName, apiKey string) string {
+	return fmt.Sprintf("apiKey: %s\nclusterName: %s\n", yamlQuote(apiKey), yamlQuote(clusterName))
+}
+
+// komodorAgentInstallCommand renders the shell command that installs or
+// upgrades the Komodor agent Helm chart in a cluster.
+func komodorAgentInstallCommand(clusterName, apiKey string) string {
+	return strings.Join([]string{
+		fmt.Sprintf("helm repo add %s %s", komodorAgentHelmRepoName, komodorAgentHelmRepoUrl),
+		"helm repo update",
+		fmt.Sprintf("helm upgrade --install %s %s --namespace %s --create-namespace --set apiKey=%s --set clusterName=%s",
+			komodorAgentHelmRelease, komodorAgentHelmChart, komodorAgentHelmNamespace, shellQuote(apiKey), shellQuote(clusterName)),
+	}, " && ")
+}
+
+// yamlQuote quotes a string as a YAML double-quoted scalar, which accepts
+// JSON string syntax.
+func yamlQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// shellQuote quotes a string for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
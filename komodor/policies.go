@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 type Resource struct {
@@ -55,12 +56,44 @@ type ResourcesScope struct {
 	SelectorsPatterns []SelectorPattern `json:"selectorsPatterns"`
 }
 
+// Statement effects. A statement without an effect allows its actions.
+const (
+	StatementEffectAllow = "allow"
+	StatementEffectDeny  = "deny"
+)
+
 type Statement struct {
 	Actions        []string        `json:"actions"`
+	Effect         string          `json:"effect,omitempty"`
 	Resources      *[]Resource     `json:"resources,omitempty"`
 	ResourcesScope *ResourcesScope `json:"resourcesScope,omitempty"`
 }
 
+// IsDeny reports whether the statement denies its actions.
+func (s Statement) IsDeny() bool {
+	return s.Effect == StatementEffectDeny
+}
+
+// verifyStatementEffects checks that the policy returned by the API still
+// denies every statement that was sent with effect = deny. An API that drops
+// the effect would otherwise store the statement as an allow.
+func verifyStatementEffects(sent []Statement, policy *Policy) error {
+	var dropped []string
+	for i, s := range sent {
+		if !s.IsDeny() {
+			continue
+		}
+		if i >= len(policy.Statements) || !policy.Statements[i].IsDeny() {
+			dropped = append(dropped, fmt.Sprintf("statements[%d]", i))
+		}
+	}
+	if len(dropped) > 0 {
+		return fmt.Errorf("the API did not store effect = %q on %s, which would allow what the statement denies",
+			StatementEffectDeny, strings.Join(dropped, ", "))
+	}
+	return nil
+}
+
 type Policy struct {
 	Id         string      `json:"id"`
 	Name       string      `json:"name"`
@@ -78,6 +111,43 @@ type NewPolicy struct {
 	Tags       interface{} `json:"tags,omitempty"`
 }
 
+func (c *Client) GetPolicies() ([]Policy, error) {
+	res, _, err := c.executeHttpRequest(http.MethodGet, c.GetPoliciesUrlV2(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []Policy
+	err = json.Unmarshal(res, &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// TagMap returns the policy tags as key/value pairs. Tags may be returned as
+// an object, or as a list of "key:value" or bare "key" strings.
+func (p Policy) TagMap() map[string]string {
+	tags := make(map[string]string)
+	switch t := p.Tags.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			tags[k] = fmt.Sprint(v)
+		}
+	case []interface{}:
+		for _, item := range t {
+			tag, ok := item.(string)
+			if !ok {
+				continue
+			}
+			key, value, _ := strings.Cut(tag, ":")
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
 func (c *Client) GetPolicy(nameOrId string) (*Policy, int, error) {
 	var policy Policy
 
@@ -0,0 +1,227 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/samber/lo"
+)
+
+// policyFinding is a problem the statement analyzer found in a policy. It is
+// reported as a warning, or as an error with strict_validation.
+type policyFinding struct {
+	Path    cty.Path
+	Summary string
+	Detail  string
+}
+
+// analyzePolicyStatements looks for patterns that fail to compile, patterns
+// whose exclude cancels their include, statements that add nothing to
+// another statement with the same effect, and allow statements that a deny
+// statement of the same policy cancels entirely.
+func analyzePolicyStatements(statements []Statement) []policyFinding {
+	var findings []policyFinding
+	valid := make([]bool, len(statements))
+
+	for i, s := range statements {
+		statementFindings := analyzeStatementPatterns(i, s.ResourcesScope)
+		valid[i] = !lo.ContainsBy(statementFindings, func(f policyFinding) bool { return f.Summary == "Invalid pattern" })
+		findings = append(findings, statementFindings...)
+	}
+
+	for i, s := range statements {
+		if !valid[i] || s.ResourcesScope == nil {
+			continue
+		}
+		for j, other := range statements {
+			if i == j || !valid[j] || other.ResourcesScope == nil {
+				continue
+			}
+			if !statementCovers(other, s) {
+				continue
+			}
+			if s.IsDeny() != other.IsDeny() {
+				if !other.IsDeny() {
+					continue
+				}
+				findings = append(findings, policyFinding{
+					Path:    cty.GetAttrPath("statements").IndexInt(i),
+					Summary: "Shadowed statement",
+					Detail: fmt.Sprintf("statements[%d] allows nothing: statements[%d] denies all of its actions on its whole resources_scope, and deny takes precedence over allow.",
+						i, j),
+				})
+				break
+			}
+			// Of two equivalent statements, only the later one is redundant.
+			if j > i && statementCovers(s, other) {
+				continue
+			}
+			findings = append(findings, policyFinding{
+				Path:    cty.GetAttrPath("statements").IndexInt(i),
+				Summary: "Redundant statement",
+				Detail: fmt.Sprintf("statements[%d] grants nothing beyond statements[%d]: its actions are a subset and its resources_scope is contained in that statement's scope.",
+					i, j),
+			})
+			break
+		}
+	}
+
+	return findings
+}
+
+// statementCovers reports whether outer names every action of inner on every
+// resource inner applies to.
+func statementCovers(outer, inner Statement) bool {
+	for _, action := range inner.Actions {
+		if !lo.Contains(outer.Actions, action) {
+			return false
+		}
+	}
+	return outer.ResourcesScope.toScope().Covers(inner.ResourcesScope.toScope())
+}
+
+func analyzeStatementPatterns(idx int, rs *ResourcesScope) []policyFinding {
+	return analyzeScopePatterns(cty.GetAttrPath("statements").IndexInt(idx).GetAttr("resources_scope").IndexInt(0), rs)
+}
+
+// analyzeScopePatterns reports the patterns of an RBAC or workspace scope that
+// fail to compile or match nothing.
+func analyzeScopePatterns(scopePath cty.Path, rs *ResourcesScope) []policyFinding {
+	if rs == nil {
+		return nil
+	}
+
+	var findings []policyFinding
+	check := func(path cty.Path, p Pattern) {
+		pattern := scope.Pattern{Include: p.Include, Exclude: p.Exclude}
+		if err := pattern.Validate(); err != nil {
+			findings = append(findings, policyFinding{Path: path, Summary: "Invalid pattern", Detail: err.Error()})
+			return
+		}
+		if pattern.MatchesNothing() {
+			findings = append(findings, policyFinding{
+				Path:    path,
+				Summary: "Pattern matches nothing",
+				Detail:  fmt.Sprintf("include = %q, exclude = %q: the exclude pattern removes everything the include pattern matches.", p.Include, p.Exclude),
+			})
+		}
+	}
+
+	for i, p := range rs.ClustersPatterns {
+		check(scopePath.GetAttr("clusters_patterns").IndexInt(i), p)
+	}
+	for i, p := range rs.NamespacesPatterns {
+		check(scopePath.GetAttr("namespaces_patterns").IndexInt(i), p)
+	}
+	for i, sp := range rs.SelectorsPatterns {
+		check(scopePath.GetAttr("selectors_patterns").IndexInt(i).GetAttr("value").IndexInt(0), sp.Value)
+	}
+
+	return findings
+}
+
+func formatPolicyFinding(f policyFinding) string {
+	return fmt.Sprintf("%s: %s: %s", formatCtyPath(f.Path), f.Summary, f.Detail)
+}
+
+func formatCtyPath(path cty.Path) string {
+	var b strings.Builder
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() == cty.Number {
+				b.WriteString(fmt.Sprintf("[%s]", s.Key.AsBigFloat().String()))
+			}
+		}
+	}
+	return b.String()
+}
+
+func resourceKomodorPolicyV2CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.Get("strict_validation").(bool) {
+		return nil
+	}
+	if statements := d.GetRawConfig().GetAttr("statements"); !statements.IsWhollyKnown() {
+		return nil
+	}
+
+	findings := analyzePolicyStatements(expandStatements(d.Get("statements").([]interface{})))
+	if len(findings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("strict_validation found %d problem(s) in statements:\n  - %s",
+		len(findings), strings.Join(lo.Map(findings, func(f policyFinding, _ int) string { return formatPolicyFinding(f) }), "\n  - "))
+}
+
+// validatePolicyV2StatementsConfig reports analyzer findings as warnings.
+// CustomizeDiff can only fail a plan, so warnings are raised while the
+// configuration is validated instead.
+func validatePolicyV2StatementsConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if config.IsNull() || !config.IsKnown() {
+		return
+	}
+	if strict := config.GetAttr("strict_validation"); strict.IsKnown() && !strict.IsNull() && strict.True() {
+		return
+	}
+	statements := config.GetAttr("statements")
+	if statements.IsNull() || !statements.IsWhollyKnown() {
+		return
+	}
+
+	raw, _ := ctyConfigToInterface(statements).([]interface{})
+	for _, f := range analyzePolicyStatements(expandStatements(raw)) {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       f.Summary,
+			Detail:        f.Detail,
+			AttributePath: f.Path,
+		})
+	}
+}
+
+// ctyConfigToInterface converts a raw configuration value into the shape
+// ResourceData.Get returns, with null values replaced by their zero value.
+func ctyConfigToInterface(v cty.Value) interface{} {
+	t := v.Type()
+	switch {
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		list := make([]interface{}, 0)
+		if v.IsNull() {
+			return list
+		}
+		for it := v.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			list = append(list, ctyConfigToInterface(elem))
+		}
+		return list
+	case t.IsObjectType():
+		m := make(map[string]interface{}, len(t.AttributeTypes()))
+		for name, attrType := range t.AttributeTypes() {
+			if v.IsNull() {
+				m[name] = ctyConfigToInterface(cty.NullVal(attrType))
+			} else {
+				m[name] = ctyConfigToInterface(v.GetAttr(name))
+			}
+		}
+		return m
+	case t == cty.String:
+		if v.IsNull() {
+			return ""
+		}
+		return v.AsString()
+	case t == cty.Bool:
+		return !v.IsNull() && v.True()
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package komodor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePolicyStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []Statement
+		want       []string
+	}{
+		{
+			name: "independent statements",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"staging"}}},
+			},
+			want: nil,
+		},
+		{
+			name: "statement shadowed by a wider one",
+			statements: []Statement{
+				{Actions: []string{"view:all", "edit:deployments"}, ResourcesScope: &ResourcesScope{ClustersPatterns: []Pattern{{Include: "prod-*"}}}},
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod-eu"}, Namespaces: []string{"default"}}},
+			},
+			want: []string{"statements[1]: Redundant statement"},
+		},
+		{
+			name: "wider scope with fewer actions is not redundant",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{ClustersPatterns: []Pattern{{Include: "*"}}}},
+				{Actions: []string{"view:all", "edit:deployments"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+			},
+			want: nil,
+		},
+		{
+			name: "duplicate statements report the later one",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+			},
+			want: []string{"statements[1]: Redundant statement"},
+		},
+		{
+			name: "allow cancelled by deny",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}, Namespaces: []string{"secrets"}}},
+				{Actions: []string{"view:all"}, Effect: StatementEffectDeny, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+			},
+			want: []string{"statements[0]: Shadowed statement"},
+		},
+		{
+			name: "deny carving out part of an allow",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}}},
+				{Actions: []string{"view:all"}, Effect: StatementEffectDeny, ResourcesScope: &ResourcesScope{Clusters: []string{"prod"}, NamespacesPatterns: []Pattern{{Include: "secrets-*"}}}},
+			},
+			want: nil,
+		},
+		{
+			name: "exclude cancels include",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{ClustersPatterns: []Pattern{{Include: "prod-*", Exclude: "*"}}}},
+			},
+			want: []string{"statements[0].resources_scope[0].clusters_patterns[0]: Pattern matches nothing"},
+		},
+		{
+			name: "invalid selector pattern",
+			statements: []Statement{
+				{Actions: []string{"view:all"}, ResourcesScope: &ResourcesScope{SelectorsPatterns: []SelectorPattern{
+					{Key: "team", Type: "label", Value: Pattern{Include: "team-["}},
+				}}},
+			},
+			want: []string{"statements[0].resources_scope[0].selectors_patterns[0].value[0]: Invalid pattern"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := lo.Map(analyzePolicyStatements(tc.statements), func(f policyFinding, _ int) string {
+				return formatCtyPath(f.Path) + ": " + f.Summary
+			})
+			if tc.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidatePolicyV2StatementsConfig(t *testing.T) {
+	configType := resourceKomodorPolicyV2().CoreConfigSchema().ImpliedType()
+	config := func(strict bool) cty.Value {
+		return testCtyValue(configType, map[string]interface{}{
+			"name":              "test-policy",
+			"strict_validation": strict,
+			"statements": []interface{}{
+				map[string]interface{}{
+					"actions": []interface{}{"view:all"},
+					"resources_scope": []interface{}{
+						map[string]interface{}{
+							"clusters_patterns": []interface{}{
+								map[string]interface{}{"include": "prod-*", "exclude": "prod-*"},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	validatePolicyV2StatementsConfig(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: config(false)}, resp)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+	assert.Equal(t, "Pattern matches nothing", resp.Diagnostics[0].Summary)
+
+	resp = &schema.ValidateResourceConfigFuncResponse{}
+	validatePolicyV2StatementsConfig(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: config(true)}, resp)
+	assert.Empty(t, resp.Diagnostics, "strict_validation reports findings as plan errors instead")
+}
+
+// testCtyValue builds a configuration value of type t, leaving attributes
+// missing from v null.
+func testCtyValue(t cty.Type, v interface{}) cty.Value {
+	if v == nil {
+		return cty.NullVal(t)
+	}
+	switch {
+	case t.IsObjectType():
+		m := v.(map[string]interface{})
+		attrs := make(map[string]cty.Value, len(t.AttributeTypes()))
+		for name, attrType := range t.AttributeTypes() {
+			attrs[name] = testCtyValue(attrType, m[name])
+		}
+		return cty.ObjectVal(attrs)
+	case t.IsListType():
+		list := v.([]interface{})
+		if len(list) == 0 {
+			return cty.ListValEmpty(t.ElementType())
+		}
+		elems := lo.Map(list, func(e interface{}, _ int) cty.Value { return testCtyValue(t.ElementType(), e) })
+		return cty.ListVal(elems)
+	case t == cty.Bool:
+		return cty.BoolVal(v.(bool))
+	}
+	return cty.StringVal(v.(string))
+}
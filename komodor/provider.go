@@ -52,20 +52,35 @@ func Provider() *schema.Provider {
 			"komodor_monitor":                  resourceKomodorMonitor(),
 			"komodor_action":                   resourceKomodorCustomK8sAction(),
 			"komodor_kubernetes":               resourceKomodorKubernetes(),
+			"komodor_kubernetes_ready":         resourceKomodorKubernetesReady(),
 			"komodor_workspace":                resourceKomodorWorkspace(),
 			"komodor_user":                     resourceKomodorUser(),
 			"komodor_klaudia_skill":            resourceKomodorKlaudiaSkill(),
 			"komodor_mcp_integration":          resourceKomodorMCPIntegration(),
 			"komodor_cost_right_sizing_policy": resourceKomodorCostRightSizingPolicy(),
+			"komodor_rbac_bundle":              resourceKomodorRbacBundle(),
+			"komodor_default_role_membership":  resourceKomodorDefaultRoleMembership(),
+			"komodor_sso_group_role_mapping":   resourceKomodorSsoGroupRoleMapping(),
+			"komodor_user_set":                 resourceKomodorUserSet(),
+			"komodor_service_account":          resourceKomodorServiceAccount(),
+			"komodor_api_key":                  resourceKomodorApiKey(),
+			"komodor_workspace_access":         resourceKomodorWorkspaceAccess(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"komodor_role":                     dataSourceKomodorRole(),
-			"komodor_policy_v2":                dataSourceKomodorPolicyV2(),
-			"komodor_kubernetes":               dataSourceKomodorKubernetes(),
-			"komodor_user":                     dataSourceKomodorUser(),
-			"komodor_workspace":                dataSourceKomodorWorkspace(),
-			"komodor_cost_right_sizing_policy": dataSourceKomodorCostRightSizingPolicy(),
+			"komodor_role":                       dataSourceKomodorRole(),
+			"komodor_policy_v2":                  dataSourceKomodorPolicyV2(),
+			"komodor_kubernetes":                 dataSourceKomodorKubernetes(),
+			"komodor_clusters":                   dataSourceKomodorClusters(),
+			"komodor_user":                       dataSourceKomodorUser(),
+			"komodor_workspace":                  dataSourceKomodorWorkspace(),
+			"komodor_workspaces":                 dataSourceKomodorWorkspaces(),
+			"komodor_cost_right_sizing_policy":   dataSourceKomodorCostRightSizingPolicy(),
+			"komodor_user_effective_permissions": dataSourceKomodorUserEffectivePermissions(),
+			"komodor_roles":                      dataSourceKomodorRoles(),
+			"komodor_policies":                   dataSourceKomodorPolicies(),
+			"komodor_sso_group_role_mapping":     dataSourceKomodorSsoGroupRoleMapping(),
+			"komodor_users":                      dataSourceKomodorUsers(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -0,0 +1,81 @@
+package komodor
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+var _ provider.ProviderWithFunctions = (*frameworkProvider)(nil)
+
+// frameworkProvider serves the parts of the provider the plugin SDK cannot,
+// such as provider-defined functions. It is muxed with Provider() and must
+// declare the same provider schema.
+type frameworkProvider struct{}
+
+// NewFrameworkProvider returns the plugin-framework half of the provider.
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "komodor"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	sdkSchema := Provider().Schema
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"api_key": fwschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["api_key"].Description,
+			},
+			"api_url": fwschema.StringAttribute{
+				Optional:    true,
+				Description: sdkSchema["api_url"].Description,
+			},
+		},
+	}
+}
+
+// Configure is a no-op: the SDK provider validates and uses the provider
+// configuration, and functions never depend on it.
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, _ *provider.ConfigureResponse) {
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewScopeMatchesFunction,
+	}
+}
+
+// NewProviderServer combines the SDK and framework providers into the single
+// protocol 5 server Terraform talks to.
+func NewProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	providers := []func() tfprotov5.ProviderServer{
+		Provider().GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider()),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
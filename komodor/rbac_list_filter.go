@@ -0,0 +1,89 @@
+package komodor
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	rbacKindAll     = "all"
+	rbacKindDefault = "default"
+	rbacKindCustom  = "custom"
+)
+
+// rbacListFilterSchema returns the filter arguments shared by the RBAC list
+// data sources. tagsDescription explains what the tags are matched against.
+func rbacListFilterSchema(tagsDescription string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+			Description:  "A regular expression the name must match. Unanchored unless `^` and `$` are used.",
+		},
+		"kind": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      rbacKindAll,
+			ValidateFunc: validation.StringInSlice([]string{rbacKindAll, rbacKindDefault, rbacKindCustom}, false),
+			Description:  "Restrict the results to Komodor's built-in (`default`) or user-defined (`custom`) objects. Defaults to `all`.",
+		},
+		"tags": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: tagsDescription,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the matching objects.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+type rbacListFilter struct {
+	nameRegex *regexp.Regexp
+	kind      string
+	tags      map[string]string
+}
+
+func expandRbacListFilter(d *schema.ResourceData) (*rbacListFilter, error) {
+	filter := &rbacListFilter{
+		kind: d.Get("kind").(string),
+		tags: make(map[string]string),
+	}
+	if expr := d.Get("name_regex").(string); expr != "" {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, err
+		}
+		filter.nameRegex = re
+	}
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		filter.tags[k] = v.(string)
+	}
+	return filter, nil
+}
+
+func (f *rbacListFilter) matches(name string, isDefault bool, tags map[string]string) bool {
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+	if (f.kind == rbacKindDefault && !isDefault) || (f.kind == rbacKindCustom && isDefault) {
+		return false
+	}
+	for k, v := range f.tags {
+		if value, ok := tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
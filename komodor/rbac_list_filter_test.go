@@ -0,0 +1,41 @@
+package komodor
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRbacListFilterMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    rbacListFilter
+		itemName  string
+		isDefault bool
+		tags      map[string]string
+		want      bool
+	}{
+		{name: "no filters", filter: rbacListFilter{kind: rbacKindAll}, itemName: "admin", want: true},
+		{name: "name regex match", filter: rbacListFilter{kind: rbacKindAll, nameRegex: regexp.MustCompile("^team-")}, itemName: "team-a", want: true},
+		{name: "name regex mismatch", filter: rbacListFilter{kind: rbacKindAll, nameRegex: regexp.MustCompile("^team-")}, itemName: "admin", want: false},
+		{name: "default only keeps default", filter: rbacListFilter{kind: rbacKindDefault}, itemName: "admin", isDefault: true, want: true},
+		{name: "default only drops custom", filter: rbacListFilter{kind: rbacKindDefault}, itemName: "team-a", want: false},
+		{name: "custom only drops default", filter: rbacListFilter{kind: rbacKindCustom}, itemName: "admin", isDefault: true, want: false},
+		{name: "tags match", filter: rbacListFilter{kind: rbacKindAll, tags: map[string]string{"owner": "sec"}}, itemName: "a", tags: map[string]string{"owner": "sec", "env": "prod"}, want: true},
+		{name: "tag value differs", filter: rbacListFilter{kind: rbacKindAll, tags: map[string]string{"owner": "sec"}}, itemName: "a", tags: map[string]string{"owner": "platform"}, want: false},
+		{name: "tag missing", filter: rbacListFilter{kind: rbacKindAll, tags: map[string]string{"owner": "sec"}}, itemName: "a", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.filter.matches(tc.itemName, tc.isDefault, tc.tags))
+		})
+	}
+}
+
+func TestPolicyTagMap(t *testing.T) {
+	assert.Equal(t, map[string]string{"owner": "sec", "pci": ""}, Policy{Tags: []interface{}{"owner:sec", "pci"}}.TagMap())
+	assert.Equal(t, map[string]string{"owner": "sec"}, Policy{Tags: map[string]interface{}{"owner": "sec"}}.TagMap())
+	assert.Equal(t, map[string]string{}, Policy{}.TagMap())
+}
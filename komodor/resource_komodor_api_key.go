@@ -0,0 +1,186 @@
+package komodor
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKomodorApiKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Issues a Komodor API key for a service account.\n\n" +
+			"Komodor only returns the key when it is created, so the value kept in state is never refreshed. " +
+			"The key is rotated by replacing the resource, either once it is older than `rotation_days` or whenever a value in `keepers` changes.",
+		CreateContext: resourceKomodorApiKeyCreate,
+		ReadContext:   resourceKomodorApiKeyRead,
+		UpdateContext: resourceKomodorApiKeyUpdate,
+		DeleteContext: resourceKomodorApiKeyDelete,
+		CustomizeDiff: resourceKomodorApiKeyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"service_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the service account the key authenticates as.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the API key.",
+			},
+			"rotation_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of days after which the key is rotated. Once the key is older than this, the next plan replaces it.",
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, rotate the key.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key. Komodor only returns it when the key is created, so it is empty for imported keys. Stored in plaintext in the Terraform state.",
+			},
+			"key_prefix": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The first characters of the key, to identify it without revealing it.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the key was created.",
+			},
+			"last_used_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the key was last used. Empty if it was never used.",
+			},
+		},
+	}
+}
+
+// apiKeyRotationDue reports whether a key created at createdAt is older than
+// rotationDays. Keys without rotation_days, or with a creation time we can't
+// parse, are never due.
+func apiKeyRotationDue(createdAt string, rotationDays int, now time.Time) bool {
+	if rotationDays <= 0 || createdAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		log.Printf("[WARN] Could not parse API key creation time %q: %s", createdAt, err)
+		return false
+	}
+	return !now.Before(t.AddDate(0, 0, rotationDays))
+}
+
+func resourceKomodorApiKeyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if !apiKeyRotationDue(d.Get("created_at").(string), d.Get("rotation_days").(int), time.Now()) {
+		return nil
+	}
+	log.Printf("[INFO] API key %s is older than rotation_days - planning rotation", d.Id())
+	if err := d.SetNewComputed("key"); err != nil {
+		return err
+	}
+	// Imported keys have no value in state, so replacement is forced through
+	// created_at, which rotation always has.
+	if err := d.SetNewComputed("created_at"); err != nil {
+		return err
+	}
+	return d.ForceNew("created_at")
+}
+
+func resourceKomodorApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	apiKey, err := client.CreateApiKey(&NewApiKey{
+		Name:             d.Get("name").(string),
+		ServiceAccountId: d.Get("service_account_id").(string),
+	})
+	if err != nil {
+		return diag.Errorf("Error creating API key: %s", err)
+	}
+
+	d.SetId(apiKey.Id)
+	if err := d.Set("key", apiKey.Key); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] API key created successfully. API key Id: %s", apiKey.Id)
+
+	return resourceKomodorApiKeyRead(ctx, d, meta)
+}
+
+func resourceKomodorApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	apiKey, statusCode, err := client.GetApiKey(id)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] API key (%s) was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading API key: %s", err)
+	}
+
+	if err := d.Set("name", apiKey.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_account_id", apiKey.ServiceAccountId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key_prefix", apiKey.KeyPrefix); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("created_at", apiKey.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("last_used_at", apiKey.LastUsedAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceKomodorApiKeyUpdate only handles rotation_days, which is evaluated
+// at plan time and never sent to the API.
+func resourceKomodorApiKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceKomodorApiKeyRead(ctx, d, meta)
+}
+
+func resourceKomodorApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	log.Printf("[INFO] Deleting API key: %s", id)
+	if err := client.DeleteApiKey(id); err != nil {
+		return diag.Errorf("Error deleting API key: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,93 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	registerAccTest("komodor_api_key")
+}
+
+func TestAcc_komodor_api_key_basic(t *testing.T) {
+	saName := testResourceName("api-key-sa")
+	keyName := testResourceName("api-key")
+	resourceAddr := "komodor_api_key.test"
+	var firstKeyId, secondKeyId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckApiKeysDestroyed(&firstKeyId, &secondKeyId),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApiKeyConfig(saName, keyName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceAddr, &firstKeyId),
+					resource.TestCheckResourceAttrPair(resourceAddr, "service_account_id", "komodor_service_account.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "key"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "key_prefix"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "created_at"),
+				),
+			},
+			// Changing a keeper rotates the key
+			{
+				Config: testAccApiKeyConfig(saName, keyName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceAddr, &secondKeyId),
+					resource.TestCheckResourceAttr(resourceAddr, "keepers.generation", "2"),
+					func(s *terraform.State) error {
+						if firstKeyId == secondKeyId {
+							return fmt.Errorf("expected a new API key after changing keepers, got %s again", firstKeyId)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            resourceAddr,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key", "keepers", "rotation_days"},
+			},
+		},
+	})
+}
+
+func testAccCheckApiKeysDestroyed(ids ...*string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		for _, id := range ids {
+			_, statusCode, err := client.GetApiKey(*id)
+			if statusCode == 404 {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("error checking API key destruction: %s", err)
+			}
+			return fmt.Errorf("API key %q still exists after destroy", *id)
+		}
+		return nil
+	}
+}
+
+func testAccApiKeyConfig(saName, keyName, generation string) string {
+	return fmt.Sprintf(`
+resource "komodor_service_account" "test" {
+  name = %q
+}
+
+resource "komodor_api_key" "test" {
+  service_account_id = komodor_service_account.test.id
+  name               = %q
+  rotation_days      = 90
+
+  keepers = {
+    generation = %q
+  }
+}
+`, saName, keyName, generation)
+}
@@ -0,0 +1,311 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func resourceKomodorDefaultRoleMembership() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the users bound to, and the policies attached to, one of Komodor's default roles.\n\n" +
+			"The role itself is adopted by name and never created, updated or deleted. Only the listed members and policies are managed: " +
+			"others bound to the role are left alone, and members or policies that were already in place when Terraform adopted them " +
+			"are kept when they are removed from the configuration or the resource is destroyed.",
+		CreateContext: resourceKomodorDefaultRoleMembershipCreate,
+		ReadContext:   resourceKomodorDefaultRoleMembershipRead,
+		UpdateContext: resourceKomodorDefaultRoleMembershipUpdate,
+		DeleteContext: resourceKomodorDefaultRoleMembershipDelete,
+
+		Schema: map[string]*schema.Schema{
+			"role_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the default role, e.g. `account-admin`.",
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of user IDs or emails to bind to the role.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"policies": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of policy IDs to attach to the role.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the default role.",
+			},
+			"preexisting_members": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Entries of `members` that were already bound to the role when Terraform adopted them. They are never unbound.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"preexisting_policies": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Entries of `policies` that were already attached to the role when Terraform adopted them. They are never detached.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+// adoptDefaultRoleMembers binds members to the role and returns the ones that
+// were already bound, which Terraform must leave in place. Each binding it
+// makes is appended to undo, so that a failure part-way can be rolled back.
+func (c *Client) adoptDefaultRoleMembers(roleId string, members []*string, undo *[]func() error) ([]string, error) {
+	preexisting := make([]string, 0)
+	for _, member := range members {
+		user, _, err := c.GetUser(*member)
+		if err != nil {
+			return nil, err
+		}
+		if lo.ContainsBy(user.Roles, func(r UserRoleResponse) bool { return r.Id == roleId }) {
+			preexisting = append(preexisting, *member)
+			continue
+		}
+		if err := c.AttachUserToRole(*member, roleId, ""); err != nil {
+			return nil, err
+		}
+		userId := *member
+		*undo = append(*undo, func() error {
+			if err := c.DetachUserFromRole(userId, roleId); err != nil {
+				return fmt.Errorf("could not unbind user %s from role %s: %w", userId, roleId, err)
+			}
+			return nil
+		})
+	}
+	return preexisting, nil
+}
+
+// adoptDefaultRolePolicies attaches policies to the role and returns the ones
+// that were already attached, which Terraform must leave in place. Each
+// attachment it makes is appended to undo.
+func (c *Client) adoptDefaultRolePolicies(role *Role, policies []*string, undo *[]func() error) ([]string, error) {
+	preexisting := make([]string, 0)
+	for _, policyId := range policies {
+		if lo.ContainsBy(role.Policies, func(p PolicyRole) bool { return p.Id == *policyId }) {
+			preexisting = append(preexisting, *policyId)
+			continue
+		}
+		if err := c.AttachPolicy(*policyId, role.Id); err != nil {
+			return nil, err
+		}
+		id := *policyId
+		*undo = append(*undo, func() error {
+			if err := c.DetachPolicy(id, role.Id); err != nil {
+				return fmt.Errorf("could not detach policy %s from role %s: %w", id, role.Id, err)
+			}
+			return nil
+		})
+	}
+	return preexisting, nil
+}
+
+func resourceKomodorDefaultRoleMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleName := d.Get("role_name").(string)
+
+	role, err := client.GetRoleByName(roleName)
+	if err != nil {
+		return diag.Errorf("Error reading role %s: %s", roleName, err)
+	}
+	if role == nil {
+		return diag.Errorf("Role %s not found", roleName)
+	}
+	if !role.IsDefault {
+		return diag.Errorf("Role %s is not a default role; manage it with komodor_role and komodor_user_role_binding instead", roleName)
+	}
+
+	// Nothing is in state until every entry is adopted, so a retry would
+	// record the entries bound so far as preexisting and never remove them.
+	// Undo them instead.
+	var undo []func() error
+	preexistingMembers, err := client.adoptDefaultRoleMembers(role.Id, ExpandStringSet(d.Get("members").(*schema.Set)), &undo)
+	if err != nil {
+		return append(diag.Errorf("Error binding users to role %s: %s", roleName, err), rollback(undo)...)
+	}
+	preexistingPolicies, err := client.adoptDefaultRolePolicies(role, ExpandStringSet(d.Get("policies").(*schema.Set)), &undo)
+	if err != nil {
+		return append(diag.Errorf("Error attaching policies to role %s: %s", roleName, err), rollback(undo)...)
+	}
+
+	d.SetId(role.Id)
+	if err := d.Set("preexisting_members", preexistingMembers); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("preexisting_policies", preexistingPolicies); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Default role %s adopted. Role Id: %s", roleName, role.Id)
+
+	return resourceKomodorDefaultRoleMembershipRead(ctx, d, meta)
+}
+
+func resourceKomodorDefaultRoleMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+
+	role, statusCode, err := client.GetRole(roleId)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Default role (%s) was not found - removing from state", roleId)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading role: %s", err)
+	}
+
+	members := make([]string, 0)
+	for _, member := range ExpandStringSet(d.Get("members").(*schema.Set)) {
+		user, statusCode, err := client.GetUser(*member)
+		if err != nil {
+			if statusCode == 404 {
+				log.Printf("[DEBUG] Default role member (%s) was not found", *member)
+				continue
+			}
+			return diag.Errorf("Error reading user %s: %s", *member, err)
+		}
+		if lo.ContainsBy(user.Roles, func(r UserRoleResponse) bool { return r.Id == roleId }) {
+			members = append(members, *member)
+		}
+	}
+
+	policies := lo.Filter(d.Get("policies").(*schema.Set).List(), func(p interface{}, _ int) bool {
+		return lo.ContainsBy(role.Policies, func(rp PolicyRole) bool { return rp.Id == p.(string) })
+	})
+
+	if err := d.Set("role_id", role.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_name", role.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("policies", policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKomodorDefaultRoleMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+	preexistingMembers := d.Get("preexisting_members").(*schema.Set)
+	preexistingPolicies := d.Get("preexisting_policies").(*schema.Set)
+
+	if d.HasChange("members") {
+		o, n := d.GetChange("members")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		for _, member := range ExpandStringSet(os.Difference(ns)) {
+			if preexistingMembers.Contains(*member) {
+				preexistingMembers.Remove(*member)
+				continue
+			}
+			if err := client.DetachUserFromRole(*member, roleId); err != nil {
+				return diag.Errorf("Error unbinding user %s from role %s: %s", *member, roleId, err)
+			}
+		}
+		var undo []func() error
+		added, err := client.adoptDefaultRoleMembers(roleId, ExpandStringSet(ns.Difference(os)), &undo)
+		if err != nil {
+			return append(diag.Errorf("Error binding users to role %s: %s", roleId, err), rollback(undo)...)
+		}
+		for _, member := range added {
+			preexistingMembers.Add(member)
+		}
+	}
+
+	if d.HasChange("policies") {
+		role, _, err := client.GetRole(roleId)
+		if err != nil {
+			return diag.Errorf("Error reading role: %s", err)
+		}
+
+		o, n := d.GetChange("policies")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		for _, policyId := range ExpandStringSet(os.Difference(ns)) {
+			if preexistingPolicies.Contains(*policyId) {
+				preexistingPolicies.Remove(*policyId)
+				continue
+			}
+			if err := client.DetachPolicy(*policyId, roleId); err != nil {
+				return diag.Errorf("Error detaching policy %s from role %s: %s", *policyId, roleId, err)
+			}
+		}
+		var undo []func() error
+		added, err := client.adoptDefaultRolePolicies(role, ExpandStringSet(ns.Difference(os)), &undo)
+		if err != nil {
+			return append(diag.Errorf("Error attaching policies to role %s: %s", roleId, err), rollback(undo)...)
+		}
+		for _, policyId := range added {
+			preexistingPolicies.Add(policyId)
+		}
+	}
+
+	if err := d.Set("preexisting_members", preexistingMembers); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("preexisting_policies", preexistingPolicies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Default role membership %s successfully updated", roleId)
+	return resourceKomodorDefaultRoleMembershipRead(ctx, d, meta)
+}
+
+func resourceKomodorDefaultRoleMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+	preexistingMembers := d.Get("preexisting_members").(*schema.Set)
+	preexistingPolicies := d.Get("preexisting_policies").(*schema.Set)
+
+	log.Printf("[INFO] Reverting default role membership: %s", roleId)
+	for _, member := range ExpandStringSet(d.Get("members").(*schema.Set).Difference(preexistingMembers)) {
+		if err := client.DetachUserFromRole(*member, roleId); err != nil {
+			return diag.Errorf("Error unbinding user %s from role %s: %s", *member, roleId, err)
+		}
+	}
+	for _, policyId := range ExpandStringSet(d.Get("policies").(*schema.Set).Difference(preexistingPolicies)) {
+		if err := client.DetachPolicy(*policyId, roleId); err != nil {
+			return diag.Errorf("Error detaching policy %s from role %s: %s", *policyId, roleId, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
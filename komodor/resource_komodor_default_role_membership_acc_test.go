@@ -0,0 +1,78 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	registerAccTest("komodor_default_role_membership")
+}
+
+func TestAcc_komodor_default_role_membership_basic(t *testing.T) {
+	userEmail := accTestPrefix + "default-role-user@komodor-test.com"
+	resourceAddr := "komodor_default_role_membership.test"
+	var roleId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDefaultRoleMembershipReverted(&roleId, userEmail),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultRoleMembershipConfig(userEmail),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceAddr, &roleId),
+					resource.TestCheckResourceAttrPair(resourceAddr, "role_name", "data.komodor_roles.default", "roles.0.name"),
+					resource.TestCheckResourceAttr(resourceAddr, "members.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "preexisting_members.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckDefaultRoleMembershipReverted verifies that destroy kept the
+// default role and unbound the user Terraform bound to it.
+func testAccCheckDefaultRoleMembershipReverted(roleId *string, userEmail string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		if _, _, err := client.GetRole(*roleId); err != nil {
+			return fmt.Errorf("default role %s should not be deleted: %s", *roleId, err)
+		}
+		user, statusCode, err := client.GetUser(userEmail)
+		if err != nil && statusCode == 404 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading user %s: %s", userEmail, err)
+		}
+		for _, r := range user.Roles {
+			if r.Id == *roleId {
+				return fmt.Errorf("user %s is still bound to default role %s", userEmail, *roleId)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccDefaultRoleMembershipConfig(userEmail string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Default Role User"
+}
+
+data "komodor_roles" "default" {
+  kind = "default"
+}
+
+resource "komodor_default_role_membership" "test" {
+  role_name = data.komodor_roles.default.roles[0].name
+  members   = [komodor_user.test.email]
+}
+`, userEmail)
+}
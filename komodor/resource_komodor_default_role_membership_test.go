@@ -0,0 +1,103 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdoptDefaultRoleMembers(t *testing.T) {
+	var attached []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/users/"):
+			user := User{Id: strings.TrimPrefix(r.URL.Path, "/api/v2/users/")}
+			if user.Id == "already@example.com" {
+				user.Roles = []UserRoleResponse{{Id: "role-admin"}}
+			}
+			_ = json.NewEncoder(w).Encode(user)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/rbac/users/roles":
+			var req UserRoleCreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			attached = append(attached, req.UserId)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	already, added := "already@example.com", "new@example.com"
+
+	var undo []func() error
+	preexisting, err := client.adoptDefaultRoleMembers("role-admin", []*string{&already, &added}, &undo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"already@example.com"}, preexisting)
+	assert.Equal(t, []string{"new@example.com"}, attached)
+	assert.Len(t, undo, 1, "only the new binding is undone on failure")
+}
+
+func TestAdoptDefaultRolePolicies(t *testing.T) {
+	var attached []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/rbac/roles/policies" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req RolePolicy
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		attached = append(attached, req.PolicyId)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	role := &Role{Id: "role-admin", IsDefault: true, Policies: []PolicyRole{{Id: "p-default"}}}
+	existing, extra := "p-default", "p-extra"
+
+	var undo []func() error
+	preexisting, err := client.adoptDefaultRolePolicies(role, []*string{&existing, &extra}, &undo)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"p-default"}, preexisting)
+	assert.Equal(t, []string{"p-extra"}, attached)
+	assert.Len(t, undo, 1, "only the new attachment is undone on failure")
+}
+
+func TestDefaultRoleMembershipCreateRollsBackOnFailure(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/rbac/roles":
+			_ = json.NewEncoder(w).Encode([]Role{{Id: "role-admin", Name: "account-admin", IsDefault: true}})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v2/users/"):
+			_ = json.NewEncoder(w).Encode(User{Id: strings.TrimPrefix(r.URL.Path, "/api/v2/users/")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/rbac/roles/policies":
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	r := resourceKomodorDefaultRoleMembership()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"role_name": "account-admin",
+		"members":   []interface{}{"new@example.com"},
+		"policies":  []interface{}{"p-missing"},
+	})
+
+	diags := r.CreateContext(context.Background(), d, client)
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, "DELETE /api/v2/rbac/users/roles", requests[len(requests)-1],
+		"the member bound before the failure is unbound again")
+}
@@ -3,9 +3,13 @@ package komodor
 import (
 	"context"
 	"log"
+	"reflect"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceKomodorKubernetes() *schema.Resource {
@@ -14,7 +18,7 @@ func resourceKomodorKubernetes() *schema.Resource {
 			"id": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The id and api key of the cluster integration",
+				Description: "The name of the cluster",
 			},
 			"cluster_name": {
 				Type:        schema.TypeString,
@@ -22,50 +26,286 @@ func resourceKomodorKubernetes() *schema.Resource {
 				ForceNew:    true,
 				Description: "The name of the Kubernetes cluster",
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "A description of the cluster. When omitted, the description set in Komodor is left as it is.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "Labels to tag the cluster with in Komodor, e.g. its environment or region. When omitted, the labels set in Komodor are left as they are.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"agent_features": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The Komodor agent features enabled in the cluster. When omitted, Komodor's defaults apply and removing the block leaves the features as they are.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metrics": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Collect node and workload metrics",
+						},
+						"network_mapper": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Map the network traffic between workloads",
+						},
+						"actions": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Allow running actions, such as restarts and scaling, from Komodor",
+						},
+						"logs_redaction": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Redact sensitive values from the logs the agent sends",
+						},
+					},
+				},
+			},
+			"keepers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, rotate the API key in place. Adding keepers to a cluster that has none, or removing all of them, does not rotate it.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rotate_key": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				DiffSuppressFunc: suppressUnrecordedRotateKeyDefault,
+				Description:      "Changing this from `false` to `true` rotates the API key in place on the next apply. Changing it back does nothing, so set it to `false` and then `true` again to rotate once more. The first apply after an import only records the configured value.",
+			},
+			"rotation_grace_period_hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      24,
+				ValidateFunc: validation.IntBetween(0, 720),
+				Description:  "How long the previous API key keeps working after a rotation, so the agent can be rolled out with the new key. Defaults to 24.",
+			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key the Komodor agent in the cluster authenticates with",
+			},
+			"previous_api_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key before the last rotation, until its grace period ends. Empty otherwise.",
+			},
+			"previous_api_key_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When `previous_api_key` stops working.",
+			},
+			"helm_values": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Values for the Komodor agent Helm chart, as YAML. Contains the API key.",
+			},
+			"install_command": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "A shell command that installs the Komodor agent Helm chart in the cluster. Contains the API key.",
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceKomodorKubernetesV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceKomodorKubernetesStateUpgradeV0,
+			},
 		},
 		CreateContext: resourceKomodorKubernetesCreate,
 		ReadContext:   resourceKomodorKubernetesRead,
+		UpdateContext: resourceKomodorKubernetesUpdate,
 		DeleteContext: resourceKomodorKubernetesDelete,
+		CustomizeDiff: resourceKomodorKubernetesCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKomodorKubernetesImport,
+		},
 		Description: "Creates a new Komodor integration with a Kubernetes cluster.\n\n" +
 			"This integration allows Komodor to monitor and analyze the cluster's activity.",
 	}
 }
 
+// resourceKomodorKubernetesV0 is the schema before version 1, which stored
+// the API key as the resource ID.
+func resourceKomodorKubernetesV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// resourceKomodorKubernetesStateUpgradeV0 moves the API key out of the ID
+// into api_key and makes the cluster name the ID.
+func resourceKomodorKubernetesStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	rawState["api_key"] = rawState["id"]
+	rawState["id"] = rawState["cluster_name"]
+	// Without these in state, every upgraded cluster would plan a change:
+	// rotation_grace_period_hours to its default, and agent_features to
+	// unknown, as integrations of that age report no features for Read to
+	// fill in.
+	rawState["rotation_grace_period_hours"] = 24
+	rawState["agent_features"] = []interface{}{}
+	return rawState, nil
+}
+
+// changeGetter is implemented by both *schema.ResourceData and
+// *schema.ResourceDiff.
+type changeGetter interface {
+	GetChange(key string) (interface{}, interface{})
+	GetRawState() cty.Value
+}
+
+// suppressUnrecordedRotateKeyDefault hides rotate_key going from null to its
+// default: a cluster without it in state, imported or managed by an older
+// provider version, has nothing to record until it is set to true.
+func suppressUnrecordedRotateKeyDefault(_, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == "" && new == "false"
+}
+
+// kubernetesKeyRotationRequested reports whether the planned change asks for
+// the API key to be rotated. Keepers only rotate the key when they change
+// from one non-empty set to another, and rotate_key only when its previous
+// value is in state: an imported cluster, or one managed by an older provider
+// version, records the configured value on its first apply without rotating.
+func kubernetesKeyRotationRequested(d changeGetter) bool {
+	oldKeepers, newKeepers := d.GetChange("keepers")
+	if len(oldKeepers.(map[string]interface{})) > 0 && len(newKeepers.(map[string]interface{})) > 0 && !reflect.DeepEqual(oldKeepers, newKeepers) {
+		return true
+	}
+	if state := d.GetRawState(); !state.IsNull() && state.GetAttr("rotate_key").IsNull() {
+		return false
+	}
+	oldRotate, newRotate := d.GetChange("rotate_key")
+	return !oldRotate.(bool) && newRotate.(bool)
+}
+
+func resourceKomodorKubernetesCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !kubernetesKeyRotationRequested(d) {
+		return nil
+	}
+	for _, key := range []string{"api_key", "previous_api_key", "previous_api_key_expires_at", "helm_values", "install_command"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceKomodorKubernetesImport sets rotation_grace_period_hours to its
+// default. rotate_key is left out of state, so that the first apply records
+// the configured value instead of treating it as a request to rotate.
+func resourceKomodorKubernetesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("rotation_grace_period_hours", 24); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceKomodorKubernetesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*Client)
 	clusterName := d.Get("cluster_name").(string)
 
-	kubernetes, err := c.CreateKubernetesCluster(clusterName)
+	_, err := c.CreateKubernetesCluster(clusterName, expandKubernetesSettings(d))
 	if err != nil {
 		return diag.Errorf("Error onboarding Kubernetes cluster: %s", err)
 	}
 
-	d.SetId(kubernetes.Id)
+	d.SetId(clusterName)
 
 	log.Printf("[INFO] Kubernetes cluster created successfully: %s", clusterName)
 
 	return resourceKomodorKubernetesRead(ctx, d, meta)
 }
 
+func resourceKomodorKubernetesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+	clusterName := d.Id()
+
+	if d.HasChanges("description", "labels", "agent_features") {
+		if _, err := c.UpdateKubernetesCluster(clusterName, expandKubernetesSettings(d)); err != nil {
+			return diag.Errorf("Error updating Kubernetes cluster: %s", err)
+		}
+		log.Printf("[INFO] Kubernetes cluster %s successfully updated", clusterName)
+	}
+
+	if kubernetesKeyRotationRequested(d) {
+		oldKey, _ := d.GetChange("api_key")
+		gracePeriodHours := d.Get("rotation_grace_period_hours").(int)
+		kubernetes, err := c.RotateKubernetesApiKey(clusterName, gracePeriodHours)
+		if err != nil {
+			return diag.Errorf("Error rotating the API key of Kubernetes cluster %s: %s", clusterName, err)
+		}
+		if kubernetes.PreviousApiKey == "" {
+			kubernetes.PreviousApiKey = oldKey.(string)
+		}
+		if kubernetes.PreviousApiKeyExpiresAt == "" {
+			kubernetes.PreviousApiKeyExpiresAt = time.Now().Add(time.Duration(gracePeriodHours) * time.Hour).UTC().Format(time.RFC3339)
+		}
+		if err := d.Set("previous_api_key", kubernetes.PreviousApiKey); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("previous_api_key_expires_at", kubernetes.PreviousApiKeyExpiresAt); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("[INFO] API key of Kubernetes cluster %s rotated", clusterName)
+	}
+
+	return resourceKomodorKubernetesRead(ctx, d, meta)
+}
+
 func resourceKomodorKubernetesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*Client)
 	clusterName := d.Id()
 
 	log.Printf("[INFO] Deleting Kubernetes cluster: %s", clusterName)
-	if err := c.DeleteKubernetesCluster(clusterName); err != nil {
+	// The integration is deleted by its API key, which used to be the ID.
+	if err := c.DeleteKubernetesCluster(d.Get("api_key").(string)); err != nil {
 		return diag.Errorf("Error deleting Kubernetes cluster: %s", err)
 	}
 
-	if err := d.Set("cluster_name", ""); err != nil {
-		return diag.Errorf("error clearing cluster_name: %s", err)
-	}
-
+	d.SetId("")
 	return nil
 }
 
 func resourceKomodorKubernetesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*Client)
-	clusterName := d.Get("cluster_name").(string)
+	clusterName := d.Id()
 
 	kubernetes, statusCode, err := c.GetKubernetesCluster(clusterName)
 	if err != nil {
@@ -78,7 +318,83 @@ func resourceKomodorKubernetesRead(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Error reading Kubernetes cluster: %s", err)
 	}
 
-	d.SetId(kubernetes.Id)
+	return flattenKubernetes(d, clusterName, kubernetes)
+}
 
+func expandKubernetesSettings(d *schema.ResourceData) *KubernetesSettings {
+	settings := &KubernetesSettings{
+		Description: d.Get("description").(string),
+		Labels:      make(map[string]string),
+	}
+	for k, v := range d.Get("labels").(map[string]interface{}) {
+		settings.Labels[k] = v.(string)
+	}
+	if features := d.Get("agent_features").([]interface{}); len(features) > 0 && features[0] != nil {
+		f := features[0].(map[string]interface{})
+		settings.Features = &KubernetesAgentFeatures{
+			Metrics:       f["metrics"].(bool),
+			NetworkMapper: f["network_mapper"].(bool),
+			Actions:       f["actions"].(bool),
+			LogsRedaction: f["logs_redaction"].(bool),
+		}
+	}
+	return settings
+}
+
+func flattenKubernetesAgentFeatures(features *KubernetesAgentFeatures) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"metrics":        features.Metrics,
+		"network_mapper": features.NetworkMapper,
+		"actions":        features.Actions,
+		"logs_redaction": features.LogsRedaction,
+	}}
+}
+
+func flattenKubernetes(d *schema.ResourceData, clusterName string, kubernetes *Kubernetes) diag.Diagnostics {
+	if err := d.Set("cluster_name", clusterName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", kubernetes.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("labels", kubernetes.Labels); err != nil {
+		return diag.FromErr(err)
+	}
+	// Integrations that predate agent features report none; keep the configured ones.
+	if kubernetes.Features != nil {
+		if err := d.Set("agent_features", flattenKubernetesAgentFeatures(kubernetes.Features)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("api_key", kubernetes.ApiKey); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := flattenKubernetesPreviousApiKey(d, kubernetes, time.Now()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("helm_values", komodorAgentHelmValues(clusterName, kubernetes.ApiKey)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("install_command", komodorAgentInstallCommand(clusterName, kubernetes.ApiKey)); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
+
+// flattenKubernetesPreviousApiKey keeps the previous API key in state until
+// its grace period ends. The API may not return it once the rotation is done,
+// so the value recorded by the rotation is kept until then.
+func flattenKubernetesPreviousApiKey(d *schema.ResourceData, kubernetes *Kubernetes, now time.Time) error {
+	previousKey := d.Get("previous_api_key").(string)
+	expiresAt := d.Get("previous_api_key_expires_at").(string)
+	if kubernetes.PreviousApiKey != "" {
+		previousKey, expiresAt = kubernetes.PreviousApiKey, kubernetes.PreviousApiKeyExpiresAt
+	}
+	if t, err := time.Parse(time.RFC3339, expiresAt); err == nil && !now.Before(t) {
+		previousKey, expiresAt = "", ""
+	}
+	if err := d.Set("previous_api_key", previousKey); err != nil {
+		return err
+	}
+	return d.Set("previous_api_key_expires_at", expiresAt)
+}
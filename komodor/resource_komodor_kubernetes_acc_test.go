@@ -15,11 +15,12 @@ func init() {
 // TestAcc_komodor_kubernetes_basic tests creation and deletion of a Kubernetes
 // integration record in the Komodor API.
 //
-// Note: komodor_kubernetes has ForceNew on cluster_name and no UpdateContext,
-// so this test only covers create and delete (no update step).
+// Only cluster_name is ForceNew; the update step changes the integration
+// settings in place and checks that the API key is kept.
 func TestAcc_komodor_kubernetes_basic(t *testing.T) {
 	clusterName := testResourceName("cluster")
 	resourceAddr := "komodor_kubernetes.test"
+	var apiKey string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -30,7 +31,31 @@ func TestAcc_komodor_kubernetes_basic(t *testing.T) {
 				Config: testAccKubernetesConfig(clusterName),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceAddr, "cluster_name", clusterName),
-					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "id", clusterName),
+					resource.TestCheckResourceAttrSet(resourceAddr, "api_key"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "helm_values"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "install_command"),
+					testAccCheckKubernetesApiKeyUnchanged(resourceAddr, &apiKey),
+				),
+			},
+			{
+				ResourceName:      resourceAddr,
+				ImportState:       true,
+				ImportStateId:     clusterName,
+				ImportStateVerify: true,
+				// Import leaves rotate_key out of state, so that the first
+				// apply records the configured value instead of rotating the
+				// key; the resource created above has it as false.
+				ImportStateVerifyIgnore: []string{"rotate_key"},
+			},
+			{
+				Config: testAccKubernetesConfigSettings(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "description", "acceptance test cluster"),
+					resource.TestCheckResourceAttr(resourceAddr, "labels.env", "tf-acc"),
+					resource.TestCheckResourceAttr(resourceAddr, "agent_features.0.network_mapper", "false"),
+					resource.TestCheckResourceAttr(resourceAddr, "agent_features.0.logs_redaction", "true"),
+					testAccCheckKubernetesApiKeyUnchanged(resourceAddr, &apiKey),
 				),
 			},
 		},
@@ -62,3 +87,103 @@ resource "komodor_kubernetes" "test" {
 }
 `, clusterName)
 }
+
+func testAccKubernetesConfigSettings(clusterName string) string {
+	return fmt.Sprintf(`
+resource "komodor_kubernetes" "test" {
+  cluster_name = %q
+  description  = "acceptance test cluster"
+  labels = {
+    env = "tf-acc"
+  }
+
+  agent_features {
+    network_mapper = false
+    logs_redaction = true
+  }
+}
+`, clusterName)
+}
+
+// testAccCheckKubernetesApiKeyUnchanged records the API key on its first call
+// and fails if a later call sees a different one.
+func testAccCheckKubernetesApiKeyUnchanged(resourceAddr string, apiKey *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceAddr]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceAddr)
+		}
+		current := rs.Primary.Attributes["api_key"]
+		if *apiKey == "" {
+			*apiKey = current
+			return nil
+		}
+		if current != *apiKey {
+			return fmt.Errorf("api_key of %s changed", resourceAddr)
+		}
+		return nil
+	}
+}
+
+func TestAcc_komodor_kubernetes_rotation(t *testing.T) {
+	clusterName := testResourceName("cluster-rotation")
+	resourceAddr := "komodor_kubernetes.test"
+	var apiKey string
+
+	config := func(keeper string, rotate bool) string {
+		return fmt.Sprintf(`
+resource "komodor_kubernetes" "test" {
+  cluster_name = %q
+  rotate_key   = %t
+  keepers = {
+    version = %q
+  }
+}
+`, clusterName, rotate, keeper)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDestroyed(clusterName),
+		Steps: []resource.TestStep{
+			{
+				Config: config("1", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKubernetesApiKeyUnchanged(resourceAddr, &apiKey),
+					resource.TestCheckResourceAttr(resourceAddr, "previous_api_key", ""),
+				),
+			},
+			// Changing keepers rotates the key in place
+			{
+				Config: config("2", false),
+				Check:  testAccCheckKubernetesApiKeyRotated(resourceAddr, &apiKey),
+			},
+			// So does setting rotate_key
+			{
+				Config: config("2", true),
+				Check:  testAccCheckKubernetesApiKeyRotated(resourceAddr, &apiKey),
+			},
+		},
+	})
+}
+
+// testAccCheckKubernetesApiKeyRotated checks that the API key changed since
+// the last check and that the old key is exposed as previous_api_key.
+func testAccCheckKubernetesApiKeyRotated(resourceAddr string, apiKey *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceAddr]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceAddr)
+		}
+		current := rs.Primary.Attributes["api_key"]
+		if current == "" || current == *apiKey {
+			return fmt.Errorf("api_key of %s was not rotated", resourceAddr)
+		}
+		if rs.Primary.Attributes["previous_api_key"] != *apiKey {
+			return fmt.Errorf("previous_api_key of %s is not the key before the rotation", resourceAddr)
+		}
+		*apiKey = current
+		return nil
+	}
+}
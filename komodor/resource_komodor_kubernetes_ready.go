@@ -0,0 +1,172 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	kubernetesStateConnected    = "connected"
+	kubernetesStateDisconnected = "disconnected"
+)
+
+// kubernetesReadyMinPollInterval is the first wait between polls of the
+// integration status. Waits then double, up to 10 seconds.
+var kubernetesReadyMinPollInterval = 2 * time.Second
+
+func resourceKomodorKubernetesReady() *schema.Resource {
+	return &schema.Resource{
+		Description: "Waits for the Komodor agent of a Kubernetes cluster to connect.\n\n" +
+			"Creating this resource blocks until the agent reports to Komodor, so resources that need data " +
+			"from the cluster, such as monitors, can depend on it. It does not change anything in Komodor.",
+		CreateContext: resourceKomodorKubernetesReadyCreate,
+		ReadContext:   resourceKomodorKubernetesReadyRead,
+		DeleteContext: resourceKomodorKubernetesReadyDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the cluster to wait for. The cluster must be onboarded, e.g. with `komodor_kubernetes`.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, wait for the agent again, e.g. the revision of the agent Helm release.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connected": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the agent is connected to Komodor.",
+			},
+			"last_heartbeat": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the agent last reported.",
+			},
+			"agent_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Komodor agent.",
+			},
+			"kubernetes_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Kubernetes version of the cluster.",
+			},
+		},
+	}
+}
+
+// kubernetesConnectionRefresh reports whether the agent of a cluster is
+// connected. Transient API errors keep the wait going; only a cluster that is
+// not onboarded ends it.
+func (c *Client) kubernetesConnectionRefresh(clusterName string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		kubernetes, statusCode, err := c.GetKubernetesCluster(clusterName)
+		if err != nil {
+			if statusCode == 404 {
+				return nil, "", fmt.Errorf("cluster %s is not onboarded to Komodor", clusterName)
+			}
+			log.Printf("[WARN] Could not check whether the agent of cluster %s is connected: %s", clusterName, err)
+			return &Kubernetes{}, kubernetesStateDisconnected, nil
+		}
+		if !kubernetes.Connected {
+			return kubernetes, kubernetesStateDisconnected, nil
+		}
+		return kubernetes, kubernetesStateConnected, nil
+	}
+}
+
+// waitForKubernetesConnection polls the integration status with exponential
+// backoff until the agent is connected, the timeout passes or ctx is done.
+func (c *Client) waitForKubernetesConnection(ctx context.Context, clusterName string, timeout time.Duration) (*Kubernetes, error) {
+	conf := &retry.StateChangeConf{
+		Pending:    []string{kubernetesStateDisconnected},
+		Target:     []string{kubernetesStateConnected},
+		Refresh:    c.kubernetesConnectionRefresh(clusterName),
+		Timeout:    timeout,
+		MinTimeout: kubernetesReadyMinPollInterval,
+	}
+	result, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*Kubernetes), nil
+}
+
+func resourceKomodorKubernetesReadyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+	clusterName := d.Get("cluster_name").(string)
+
+	log.Printf("[INFO] Waiting for the agent of Kubernetes cluster %s to connect", clusterName)
+	kubernetes, err := c.waitForKubernetesConnection(ctx, clusterName, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.Errorf("Error waiting for the agent of Kubernetes cluster %s to connect: %s", clusterName, err)
+	}
+
+	d.SetId(clusterName)
+	log.Printf("[INFO] Agent of Kubernetes cluster %s connected", clusterName)
+
+	return flattenKubernetesReady(d, kubernetes)
+}
+
+// resourceKomodorKubernetesReadyRead refreshes the status without waiting. An
+// agent that disconnects later does not change the plan; only a cluster that
+// is no longer onboarded removes the resource, so the next apply waits again.
+func resourceKomodorKubernetesReadyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*Client)
+	clusterName := d.Id()
+
+	kubernetes, statusCode, err := c.GetKubernetesCluster(clusterName)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Kubernetes cluster %s not found - removing from state", clusterName)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading Kubernetes cluster: %s", err)
+	}
+
+	if err := d.Set("cluster_name", clusterName); err != nil {
+		return diag.FromErr(err)
+	}
+	return flattenKubernetesReady(d, kubernetes)
+}
+
+func resourceKomodorKubernetesReadyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func flattenKubernetesReady(d *schema.ResourceData, kubernetes *Kubernetes) diag.Diagnostics {
+	if err := d.Set("connected", kubernetes.Connected); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("last_heartbeat", kubernetes.LastHeartbeat); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("agent_version", kubernetes.AgentVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("kubernetes_version", kubernetes.KubernetesVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
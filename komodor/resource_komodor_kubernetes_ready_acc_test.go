@@ -0,0 +1,43 @@
+package komodor
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	registerAccTest("komodor_kubernetes_ready")
+}
+
+// TestAcc_komodor_kubernetes_ready_timeout checks that the wait honours the
+// create timeout. No agent is installed, so the cluster never connects.
+func TestAcc_komodor_kubernetes_ready_timeout(t *testing.T) {
+	clusterName := testResourceName("cluster-ready")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesDestroyed(clusterName),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_kubernetes" "test" {
+  cluster_name = %q
+}
+
+resource "komodor_kubernetes_ready" "test" {
+  cluster_name = komodor_kubernetes.test.cluster_name
+
+  timeouts {
+    create = "20s"
+  }
+}
+`, clusterName),
+				ExpectError: regexp.MustCompile(`timeout while waiting for state to become 'connected'`),
+			},
+		},
+	})
+}
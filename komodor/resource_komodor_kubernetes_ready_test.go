@@ -0,0 +1,107 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKubernetesReadyTestClient serves cluster prod-eu, whose agent connects
+// on poll number connectOnPoll, after the polls listed in failOnPolls fail
+// with a 500. A connectOnPoll of 0 never connects.
+func newKubernetesReadyTestClient(t *testing.T, connectOnPoll int32, failOnPolls ...int32) (*Client, *int32) {
+	t.Helper()
+	previous := kubernetesReadyMinPollInterval
+	kubernetesReadyMinPollInterval = 0
+	t.Cleanup(func() { kubernetesReadyMinPollInterval = previous })
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/integrations/kubernetes/prod-eu" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		poll := atomic.AddInt32(&polls, 1)
+		for _, p := range failOnPolls {
+			if poll == p {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		if connectOnPoll > 0 && poll >= connectOnPoll {
+			_, _ = w.Write([]byte(`{"apiKey":"key-1","clusterName":"prod-eu","isConnected":true,"agentVersion":"2.11.1","k8sVersion":"1.31","lastHeartbeat":"2026-10-19T10:00:00Z"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"apiKey":"key-1","clusterName":"prod-eu","isConnected":false}`))
+	}))
+	t.Cleanup(server.Close)
+	return NewClient("key", server.URL), &polls
+}
+
+func TestKubernetesReadyWaitsForConnection(t *testing.T) {
+	client, polls := newKubernetesReadyTestClient(t, 4, 2)
+	r := resourceKomodorKubernetesReady()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"cluster_name": "prod-eu"})
+
+	diags := r.CreateContext(context.Background(), d, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, int32(4), atomic.LoadInt32(polls), "a failed poll does not end the wait")
+	assert.Equal(t, "prod-eu", d.Id())
+	assert.Equal(t, true, d.Get("connected"))
+	assert.Equal(t, "2.11.1", d.Get("agent_version"))
+	assert.Equal(t, "1.31", d.Get("kubernetes_version"))
+}
+
+func TestKubernetesReadyClusterNotOnboarded(t *testing.T) {
+	client, _ := newKubernetesReadyTestClient(t, 1)
+	r := resourceKomodorKubernetesReady()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"cluster_name": "unknown"})
+
+	diags := r.CreateContext(context.Background(), d, client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "cluster unknown is not onboarded")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestKubernetesReadyTimeout(t *testing.T) {
+	client, _ := newKubernetesReadyTestClient(t, 0)
+
+	start := time.Now()
+	_, err := client.waitForKubernetesConnection(context.Background(), "prod-eu", 500*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeout while waiting for state to become 'connected'")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestKubernetesReadyContextCancelled(t *testing.T) {
+	client, _ := newKubernetesReadyTestClient(t, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.waitForKubernetesConnection(ctx, "prod-eu", time.Hour)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "the wait stops with its context")
+}
+
+func TestKubernetesReadyRead(t *testing.T) {
+	client, _ := newKubernetesReadyTestClient(t, 0)
+	r := resourceKomodorKubernetesReady()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+
+	d.SetId("prod-eu")
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, "prod-eu", d.Id(), "a disconnected agent does not remove the resource")
+	assert.Equal(t, false, d.Get("connected"))
+
+	d.SetId("gone")
+	require.False(t, r.ReadContext(context.Background(), d, client).HasError())
+	assert.Equal(t, "", d.Id())
+}
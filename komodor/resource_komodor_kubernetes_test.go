@@ -0,0 +1,349 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestKubernetesStateUpgradeV0(t *testing.T) {
+	upgraded, err := resourceKomodorKubernetesStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":           "6f1c0e2a-key",
+		"cluster_name": "prod-eu",
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":                          "prod-eu",
+		"cluster_name":                "prod-eu",
+		"api_key":                     "6f1c0e2a-key",
+		"rotation_grace_period_hours": 24,
+		"agent_features":              []interface{}{},
+	}, upgraded)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Kubernetes{ApiKey: "6f1c0e2a-key", ClusterName: "prod-eu"})
+	}))
+	defer server.Close()
+	client := NewClient("key", server.URL)
+
+	r := resourceKomodorKubernetes()
+	value, err := schema.JSONMapToStateValue(upgraded, r.CoreConfigSchema())
+	require.NoError(t, err)
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), terraform.NewInstanceStateShimmedFromValue(value, r.SchemaVersion), client)
+	require.False(t, diags.HasError(), "%v", diags)
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"cluster_name": "prod-eu"}), client)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "an upgraded cluster plans no changes, got %v", diff)
+}
+
+func TestKubernetesLifecycle(t *testing.T) {
+	var deletedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/integrations/kubernetes":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"apiKey":"key-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/integrations/kubernetes/prod-eu":
+			_, _ = w.Write([]byte(`{"apiKey":"key-1"}`))
+		case r.Method == http.MethodDelete:
+			deletedPath = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	ctx := context.Background()
+	r := resourceKomodorKubernetes()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"cluster_name": "prod-eu"})
+
+	require.False(t, r.CreateContext(ctx, d, client).HasError())
+	assert.Equal(t, "prod-eu", d.Id())
+	assert.Equal(t, "key-1", d.Get("api_key"))
+	assert.Contains(t, d.Get("install_command"), "--set apiKey='key-1' --set clusterName='prod-eu'")
+
+	require.False(t, r.DeleteContext(ctx, d, client).HasError())
+	assert.Equal(t, "/api/v2/integrations/kubernetes/key-1", deletedPath)
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("prod-eu")
+	require.False(t, r.ReadContext(ctx, imported, client).HasError())
+	assert.Equal(t, "prod-eu", imported.Get("cluster_name"))
+	assert.Equal(t, "key-1", imported.Get("api_key"))
+
+	imported.SetId("gone")
+	require.False(t, r.ReadContext(ctx, imported, client).HasError())
+	assert.Equal(t, "", imported.Id())
+}
+
+func TestKubernetesSettingsUpdateInPlace(t *testing.T) {
+	var methods []string
+	stored := Kubernetes{ApiKey: "key-1"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.Method {
+		case http.MethodPost:
+			var req newKubernetes
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "prod-eu", req.ClusterName)
+			stored.KubernetesSettings = req.KubernetesSettings
+		case http.MethodPut:
+			require.Equal(t, "/api/v2/integrations/kubernetes/prod-eu", r.URL.Path)
+			stored.KubernetesSettings = KubernetesSettings{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&stored.KubernetesSettings))
+		}
+		_ = json.NewEncoder(w).Encode(stored)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	ctx := context.Background()
+	r := resourceKomodorKubernetes()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_name": "prod-eu",
+		"description":  "Production, EU",
+		"labels":       map[string]interface{}{"env": "prod"},
+	})
+
+	require.False(t, r.CreateContext(ctx, d, client).HasError())
+	assert.Nil(t, stored.Features, "Komodor's defaults apply when agent_features is omitted")
+	assert.Equal(t, map[string]string{"env": "prod"}, stored.Labels)
+	assert.Empty(t, d.Get("agent_features"))
+
+	methods = nil
+	require.NoError(t, d.Set("description", "Production, Frankfurt"))
+	require.NoError(t, d.Set("agent_features", []interface{}{map[string]interface{}{
+		"metrics":        true,
+		"network_mapper": false,
+		"actions":        true,
+		"logs_redaction": true,
+	}}))
+	require.False(t, r.UpdateContext(ctx, d, client).HasError())
+
+	assert.Equal(t, []string{http.MethodPut, http.MethodGet}, methods, "settings change without recreating the integration")
+	assert.Equal(t, "Production, Frankfurt", stored.Description)
+	assert.Equal(t, &KubernetesAgentFeatures{Metrics: true, Actions: true, LogsRedaction: true}, stored.Features)
+	assert.Equal(t, "key-1", d.Get("api_key"))
+	assert.Equal(t, false, d.Get("agent_features.0.network_mapper"))
+}
+
+func TestKubernetesSettingsDoNotForceNew(t *testing.T) {
+	r := resourceKomodorKubernetes()
+	for name, s := range r.Schema {
+		if name != "cluster_name" {
+			assert.False(t, s.ForceNew, "%s must be updatable in place", name)
+		}
+	}
+}
+
+func TestKubernetesSettingsOmittedAreKept(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "prod-eu",
+		Attributes: map[string]string{
+			"id":                          "prod-eu",
+			"cluster_name":                "prod-eu",
+			"api_key":                     "key-1",
+			"description":                 "Set in Komodor",
+			"labels.%":                    "1",
+			"labels.env":                  "prod",
+			"agent_features.#":            "0",
+			"rotate_key":                  "false",
+			"rotation_grace_period_hours": "24",
+		},
+	}
+
+	r := resourceKomodorKubernetes()
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"cluster_name": "prod-eu"}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "omitted description and labels plan no changes, got %v", diff)
+}
+
+func TestKubernetesKeyRotationPlan(t *testing.T) {
+	state := func(attrs map[string]string) *terraform.InstanceState {
+		base := map[string]string{
+			"id":                          "prod-eu",
+			"cluster_name":                "prod-eu",
+			"api_key":                     "key-1",
+			"rotate_key":                  "false",
+			"rotation_grace_period_hours": "24",
+		}
+		for k, v := range attrs {
+			base[k] = v
+		}
+		return &terraform.InstanceState{ID: "prod-eu", Attributes: base}
+	}
+
+	// An imported cluster has no rotate_key in state.
+	imported := state(nil)
+	delete(imported.Attributes, "rotate_key")
+	rawState, err := imported.AttrsAsObjectValue(resourceKomodorKubernetes().CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+	imported.RawState = rawState
+
+	tests := []struct {
+		name   string
+		state  *terraform.InstanceState
+		config map[string]interface{}
+		rotate bool
+	}{
+		{
+			name:   "keepers changed",
+			state:  state(map[string]string{"keepers.%": "1", "keepers.version": "1"}),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "keepers": map[string]interface{}{"version": "2"}},
+			rotate: true,
+		},
+		{
+			name:   "keepers unchanged",
+			state:  state(map[string]string{"keepers.%": "1", "keepers.version": "1"}),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "keepers": map[string]interface{}{"version": "1"}},
+		},
+		{
+			name:   "keepers added",
+			state:  state(nil),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "keepers": map[string]interface{}{"version": "1"}},
+		},
+		{
+			name:   "keepers removed",
+			state:  state(map[string]string{"keepers.%": "1", "keepers.version": "1"}),
+			config: map[string]interface{}{"cluster_name": "prod-eu"},
+		},
+		{
+			name:   "rotate_key set",
+			state:  state(nil),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "rotate_key": true},
+			rotate: true,
+		},
+		{
+			name:   "rotate_key kept",
+			state:  state(map[string]string{"rotate_key": "true"}),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "rotate_key": true},
+		},
+		{
+			name:   "rotate_key cleared",
+			state:  state(map[string]string{"rotate_key": "true"}),
+			config: map[string]interface{}{"cluster_name": "prod-eu", "rotate_key": false},
+		},
+		{
+			name:   "rotate_key set after import",
+			state:  imported,
+			config: map[string]interface{}{"cluster_name": "prod-eu", "rotate_key": true},
+		},
+	}
+
+	r := resourceKomodorKubernetes()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := r.SimpleDiff(context.Background(), tt.state, terraform.NewResourceConfigRaw(tt.config), nil)
+			require.NoError(t, err)
+			rotates := diff != nil && diff.Attributes["api_key"] != nil && diff.Attributes["api_key"].NewComputed
+			assert.Equal(t, tt.rotate, rotates)
+			if diff != nil {
+				assert.False(t, diff.RequiresNew(), "rotation never replaces the integration")
+			}
+		})
+	}
+}
+
+func TestKubernetesKeyRotation(t *testing.T) {
+	apiKey := "key-1"
+	var rotateRequest map[string]int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/integrations/kubernetes/prod-eu/rotate-key":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&rotateRequest))
+			apiKey = "key-2"
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/integrations/kubernetes/prod-eu":
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(Kubernetes{ApiKey: apiKey, ClusterName: "prod-eu"})
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	r := resourceKomodorKubernetes()
+	state := &terraform.InstanceState{ID: "prod-eu", Attributes: map[string]string{
+		"id":                          "prod-eu",
+		"cluster_name":                "prod-eu",
+		"api_key":                     "key-1",
+		"rotate_key":                  "false",
+		"rotation_grace_period_hours": "24",
+	}}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_name":                "prod-eu",
+		"rotate_key":                  true,
+		"rotation_grace_period_hours": 48,
+	})
+	diff, err := r.SimpleDiff(context.Background(), state, config, client)
+	require.NoError(t, err)
+
+	newState, diags := r.Apply(context.Background(), state, diff, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, map[string]int{"gracePeriodHours": 48}, rotateRequest)
+	assert.Equal(t, "key-2", newState.Attributes["api_key"])
+	assert.Equal(t, "key-1", newState.Attributes["previous_api_key"])
+	assert.NotEmpty(t, newState.Attributes["previous_api_key_expires_at"])
+	assert.Contains(t, newState.Attributes["helm_values"], `apiKey: "key-2"`)
+}
+
+func TestFlattenKubernetesPreviousApiKey(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	r := resourceKomodorKubernetes()
+
+	tests := []struct {
+		name       string
+		state      map[string]interface{}
+		kubernetes Kubernetes
+		want       string
+	}{
+		{
+			name:  "kept during the grace period",
+			state: map[string]interface{}{"previous_api_key": "key-1", "previous_api_key_expires_at": "2026-10-20T12:00:00Z"},
+			want:  "key-1",
+		},
+		{
+			name:  "cleared once the grace period ends",
+			state: map[string]interface{}{"previous_api_key": "key-1", "previous_api_key_expires_at": "2026-10-19T11:59:59Z"},
+		},
+		{
+			name:       "taken from the API",
+			state:      map[string]interface{}{},
+			kubernetes: Kubernetes{PreviousApiKey: "key-0", PreviousApiKeyExpiresAt: "2026-10-21T00:00:00Z"},
+			want:       "key-0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+			for k, v := range tt.state {
+				require.NoError(t, d.Set(k, v))
+			}
+			require.NoError(t, flattenKubernetesPreviousApiKey(d, &tt.kubernetes, now))
+			assert.Equal(t, tt.want, d.Get("previous_api_key"))
+		})
+	}
+}
+
+func TestKomodorAgentHelmValues(t *testing.T) {
+	var values map[string]string
+	require.NoError(t, yaml.Unmarshal([]byte(komodorAgentHelmValues("prod: eu", `key"1`)), &values))
+	assert.Equal(t, map[string]string{"apiKey": `key"1`, "clusterName": "prod: eu"}, values)
+}
+
+func TestKomodorAgentInstallCommandQuoting(t *testing.T) {
+	cmd := komodorAgentInstallCommand("it's-prod", "key-1")
+	assert.Contains(t, cmd, `--set clusterName='it'\''s-prod'`)
+	assert.Contains(t, cmd, "helm repo add komodorio https://helm-charts.komodor.io")
+}
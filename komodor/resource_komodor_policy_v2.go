@@ -2,6 +2,7 @@ package komodor
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,6 +18,10 @@ func resourceKomodorPolicyV2() *schema.Resource {
 		ReadContext:   resourceKomodorPolicyV2Read,
 		UpdateContext: resourceKomodorPolicyV2Update,
 		DeleteContext: resourceKomodorPolicyV2Delete,
+		CustomizeDiff: resourceKomodorPolicyV2CustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validatePolicyV2StatementsConfig,
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -28,60 +33,13 @@ func resourceKomodorPolicyV2() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 				Description:  "The name of the policy.",
 			},
-			"statements": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "One or more policy statements defining the allowed actions and resource scopes.",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"actions": {
-							Type:        schema.TypeList,
-							Required:    true,
-							Description: "List of actions permitted by this statement (e.g., `view:all`, `edit:deployments`).",
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"resources_scope": {
-							Type:        schema.TypeList,
-							Required:    true,
-							MaxItems:    1,
-							Description: "The scope of Kubernetes resources this statement applies to.",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"clusters": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										Description: "List of cluster names to include in the scope.",
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"namespaces": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										Description: "List of namespace names to include in the scope.",
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"clusters_patterns":   patternListMaxOneSchema(),
-									"namespaces_patterns": patternListMaxOneSchema(),
-									"selectors": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem:     selectorSchema(),
-									},
-									"selectors_patterns": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem:     selectorPatternSchema(),
-									},
-								},
-							},
-						},
-					},
-				},
+			"statements": policyStatementsSchema(),
+			"strict_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Fail the plan instead of warning when the statements contain invalid patterns, patterns whose `exclude` cancels their `include`, " +
+					"or statements whose actions and scope are already granted by another statement.",
 			},
 			"id": {
 				Type:        schema.TypeString,
@@ -103,6 +61,75 @@ func resourceKomodorPolicyV2() *schema.Resource {
 	}
 }
 
+// policyStatementsSchema is shared by every resource that defines RBAC
+// policy statements inline.
+func policyStatementsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		Description: "One or more policy statements defining the allowed actions and resource scopes.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"actions": {
+					Type:        schema.TypeList,
+					Required:    true,
+					Description: "List of actions permitted by this statement (e.g., `view:all`, `edit:deployments`).",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"effect": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      StatementEffectAllow,
+					ValidateFunc: validation.StringInSlice([]string{StatementEffectAllow, StatementEffectDeny}, false),
+					Description: "Whether the statement allows or denies its `actions` on its `resources_scope`. One of `allow` or `deny`. " +
+						"A deny in any policy of any of a user's roles takes precedence over every allow. " +
+						"If the API does not store a `deny`, the apply fails and the change is rolled back instead of saving the statement as an allow.",
+				},
+				"resources_scope": {
+					Type:        schema.TypeList,
+					Required:    true,
+					MaxItems:    1,
+					Description: "The scope of Kubernetes resources this statement applies to.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"clusters": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "List of cluster names to include in the scope.",
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"namespaces": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								Description: "List of namespace names to include in the scope.",
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"clusters_patterns":   patternListMaxOneSchema(),
+							"namespaces_patterns": patternListMaxOneSchema(),
+							"selectors": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     selectorSchema(),
+							},
+							"selectors_patterns": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     selectorPatternSchema(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func patternListMaxOneSchema() *schema.Schema {
 	return patternListSchema(1)
 }
@@ -177,10 +204,15 @@ func expandStatements(list []interface{}) []Statement {
 	statements := make([]Statement, 0, len(list))
 	for _, item := range list {
 		data := item.(map[string]interface{})
-		statements = append(statements, Statement{
+		statement := Statement{
 			Actions:        toStringList(data["actions"].([]interface{})),
 			ResourcesScope: expandResourcesScope(data["resources_scope"].([]interface{})),
-		})
+		}
+		// Allow is the API default, so it is left out of requests.
+		if effect, _ := data["effect"].(string); effect == StatementEffectDeny {
+			statement.Effect = StatementEffectDeny
+		}
+		statements = append(statements, statement)
 	}
 	return statements
 }
@@ -231,16 +263,18 @@ func expandSelectors(list []interface{}) []Selector {
 func expandSelectorPatterns(list []interface{}) []SelectorPattern {
 	return lo.Map(list, func(item interface{}, _ int) SelectorPattern {
 		sp := item.(map[string]interface{})
-		valueList := sp["value"].([]interface{})
-		value := valueList[0].(map[string]interface{})
-		return SelectorPattern{
+		selectorPattern := SelectorPattern{
 			Key:  sp["key"].(string),
 			Type: SelectorType(sp["type"].(string)),
-			Value: Pattern{
+		}
+		if valueList := sp["value"].([]interface{}); len(valueList) > 0 && valueList[0] != nil {
+			value := valueList[0].(map[string]interface{})
+			selectorPattern.Value = Pattern{
 				Include: value["include"].(string),
 				Exclude: value["exclude"].(string),
-			},
+			}
 		}
+		return selectorPattern
 	})
 }
 
@@ -262,6 +296,7 @@ func flattenStatements(statements []Statement) []interface{} {
 	return lo.Map(statements, func(s Statement, _ int) interface{} {
 		m := map[string]interface{}{
 			"actions": toInterfaceList(s.Actions),
+			"effect":  lo.Ternary(s.IsDeny(), StatementEffectDeny, StatementEffectAllow),
 		}
 		if s.ResourcesScope != nil {
 			m["resources_scope"] = []interface{}{flattenResourcesScope(s.ResourcesScope)}
@@ -336,6 +371,16 @@ func resourceKomodorPolicyV2Create(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Error creating policy V2: %s", err)
 	}
+	if err := verifyStatementEffects(newPolicy.Statements, policy); err != nil {
+		return append(diag.Errorf("Error creating policy V2: %s", err), rollback([]func() error{
+			func() error {
+				if err := client.DeletePolicyV2(policy.Id); err != nil {
+					return fmt.Errorf("could not delete policy %s: %w", policy.Id, err)
+				}
+				return nil
+			},
+		})...)
+	}
 
 	d.SetId(policy.Id)
 
@@ -368,15 +413,34 @@ func resourceKomodorPolicyV2Update(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*Client)
 	newPolicy := expandPolicy(d)
 
-	_, err := client.UpdatePolicyV2(d.Id(), newPolicy)
+	policy, err := client.UpdatePolicyV2(d.Id(), newPolicy)
 	if err != nil {
 		return diag.Errorf("Error updating policy: %s", err)
 	}
+	if err := verifyStatementEffects(newPolicy.Statements, policy); err != nil {
+		oldName, _ := d.GetChange("name")
+		oldStatements, _ := d.GetChange("statements")
+		return append(diag.Errorf("Error updating policy: %s", err), rollback([]func() error{
+			restorePolicy(client, d.Id(), oldName.(string), oldStatements.([]interface{})),
+		})...)
+	}
 
 	log.Printf("[INFO] Policy %s successfully updated", d.Id())
 	return resourceKomodorPolicyV2Read(ctx, d, meta)
 }
 
+// restorePolicy returns an undo step that puts back the policy as it was
+// before a failed update.
+func restorePolicy(client *Client, id string, name string, statements []interface{}) func() error {
+	return func() error {
+		previous := &NewPolicy{Name: name, Statements: expandStatements(statements)}
+		if _, err := client.UpdatePolicyV2(id, previous); err != nil {
+			return fmt.Errorf("could not restore policy %s: %w", id, err)
+		}
+		return nil
+	}
+}
+
 func resourceKomodorPolicyV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	id := d.Id()
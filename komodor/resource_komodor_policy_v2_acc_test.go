@@ -2,6 +2,7 @@ package komodor
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -34,9 +35,10 @@ func TestAcc_komodor_policy_v2_basic(t *testing.T) {
 			},
 			// Step 2: Import
 			{
-				ResourceName:      resourceAddr,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceAddr,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"strict_validation"},
 			},
 			// Step 3: Update name and add a second statement
 			{
@@ -46,6 +48,11 @@ func TestAcc_komodor_policy_v2_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceAddr, "statements.#", "2"),
 				),
 			},
+			// Step 4: Strict validation rejects a shadowed statement
+			{
+				Config:      testAccPolicyV2ConfigStrictShadowed(updatedName),
+				ExpectError: regexp.MustCompile(`Redundant statement`),
+			},
 		},
 	})
 }
@@ -115,3 +122,32 @@ resource "komodor_policy_v2" "test" {
 }
 `, name)
 }
+
+func testAccPolicyV2ConfigStrictShadowed(name string) string {
+	return fmt.Sprintf(`
+resource "komodor_policy_v2" "test" {
+  name              = %q
+  strict_validation = true
+
+  statements {
+    actions = ["view:all"]
+
+    resources_scope {
+      clusters_patterns {
+        include = "tf-acc-*"
+        exclude = ""
+      }
+    }
+  }
+
+  statements {
+    actions = ["view:all"]
+
+    resources_scope {
+      clusters   = ["tf-acc-cluster"]
+      namespaces = ["default"]
+    }
+  }
+}
+`, name)
+}
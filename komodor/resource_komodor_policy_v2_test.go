@@ -1,11 +1,16 @@
 package komodor
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestResourceKomodorPolicyV2 tests the schema expansion logic for different policy configurations.
@@ -213,6 +218,40 @@ func TestResourceKomodorPolicyV2(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "deny statement",
+			config: map[string]interface{}{
+				"name": "deny-policy",
+				"statements": []interface{}{
+					map[string]interface{}{
+						"actions": []interface{}{"view:all"},
+						"effect":  "deny",
+						"resources_scope": []interface{}{
+							map[string]interface{}{
+								"namespaces": []interface{}{"secrets"},
+							},
+						},
+					},
+				},
+			},
+			expected: &NewPolicy{
+				Name: "deny-policy",
+				Statements: []Statement{
+					{
+						Actions: []string{"view:all"},
+						Effect:  StatementEffectDeny,
+						ResourcesScope: &ResourcesScope{
+							Clusters:           []string{},
+							Namespaces:         []string{"secrets"},
+							ClustersPatterns:   []Pattern{},
+							NamespacesPatterns: []Pattern{},
+							Selectors:          []Selector{},
+							SelectorsPatterns:  []SelectorPattern{},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,6 +317,24 @@ func TestResourceKomodorPolicyV2Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid statement effect",
+			config: map[string]interface{}{
+				"name": "test-policy",
+				"statements": []interface{}{
+					map[string]interface{}{
+						"actions": []interface{}{"view:all"},
+						"effect":  "block",
+						"resources_scope": []interface{}{
+							map[string]interface{}{
+								"clusters": []interface{}{"prod-cluster"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing required actions in statement",
 			config: map[string]interface{}{
@@ -500,3 +557,102 @@ func TestResourceKomodorPolicyV2Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyStatementEffects(t *testing.T) {
+	sent := []Statement{
+		{Actions: []string{"view:all"}},
+		{Actions: []string{"delete:pod"}, Effect: StatementEffectDeny},
+	}
+
+	assert.NoError(t, verifyStatementEffects(sent, &Policy{Statements: sent}))
+
+	err := verifyStatementEffects(sent, &Policy{Statements: []Statement{
+		{Actions: []string{"view:all"}},
+		{Actions: []string{"delete:pod"}},
+	}})
+	assert.ErrorContains(t, err, "statements[1]")
+
+	assert.ErrorContains(t, verifyStatementEffects(sent, &Policy{}), "statements[1]")
+	assert.NoError(t, verifyStatementEffects(sent[:1], &Policy{}))
+}
+
+// newEffectlessPolicyServer serves policy p-1 from an API that does not know
+// about statement effects: it stores and echoes statements without them.
+// Every request is recorded as "METHOD path".
+func newEffectlessPolicyServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			return
+		}
+		var p NewPolicy
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		for i := range p.Statements {
+			p.Statements[i].Effect = ""
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(Policy{Id: "p-1", Name: p.Name, Statements: p.Statements}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testDenyPolicyConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name": "deny-deletes",
+		"statements": []interface{}{
+			map[string]interface{}{
+				"actions": []interface{}{"delete:pod"},
+				"effect":  StatementEffectDeny,
+				"resources_scope": []interface{}{
+					map[string]interface{}{"clusters": []interface{}{"prod"}},
+				},
+			},
+		},
+	}
+}
+
+func TestPolicyV2CreateRollsBackDroppedDeny(t *testing.T) {
+	var requests []string
+	client := NewClient("key", newEffectlessPolicyServer(t, &requests).URL)
+	r := resourceKomodorPolicyV2()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, testDenyPolicyConfig())
+	diags := r.CreateContext(context.Background(), d, client)
+
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "statements[0]")
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, []string{"POST /api/v2/rbac/policies", "DELETE /api/v2/rbac/policies/p-1"}, requests)
+}
+
+func TestPolicyV2UpdateRestoresOnDroppedDeny(t *testing.T) {
+	var requests []string
+	client := NewClient("key", newEffectlessPolicyServer(t, &requests).URL)
+	r := resourceKomodorPolicyV2()
+
+	state := &terraform.InstanceState{
+		ID: "p-1",
+		Attributes: map[string]string{
+			"id":                             "p-1",
+			"name":                           "deny-deletes",
+			"statements.#":                   "1",
+			"statements.0.actions.#":         "1",
+			"statements.0.actions.0":         "delete:pod",
+			"statements.0.effect":            StatementEffectAllow,
+			"statements.0.resources_scope.#": "1",
+			"statements.0.resources_scope.0.clusters.#": "1",
+			"statements.0.resources_scope.0.clusters.0": "prod",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(testDenyPolicyConfig()), client)
+	require.NoError(t, err)
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	diags := r.UpdateContext(context.Background(), d, client)
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, []string{"PUT /api/v2/rbac/policies/p-1", "PUT /api/v2/rbac/policies/p-1"}, requests,
+		"the second PUT restores the previous statements")
+}
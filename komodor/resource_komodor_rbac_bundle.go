@@ -0,0 +1,380 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func resourceKomodorRbacBundle() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Komodor RBAC Role together with an inline Policy and the Users bound to it.\n\n" +
+			"The role, policy, policy attachment and user bindings are created, updated and deleted as one unit; " +
+			"if creation fails part-way, the pieces created so far are rolled back.",
+		CreateContext: resourceKomodorRbacBundleCreate,
+		ReadContext:   resourceKomodorRbacBundleRead,
+		UpdateContext: resourceKomodorRbacBundleUpdate,
+		DeleteContext: resourceKomodorRbacBundleDelete,
+		CustomizeDiff: resourceKomodorRbacBundleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"role_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the role.",
+			},
+			"role_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A human-readable description of the role.",
+			},
+			"policy_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the policy. Defaults to `<role_name>-policy`.",
+			},
+			"statements": policyStatementsSchema(),
+			"members": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of user IDs or emails to bind to the role.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the bundle, equal to `role_id`.",
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the underlying role.",
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the underlying policy.",
+			},
+			"member_user_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of each entry in `members` to the ID of the user it resolved to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func rbacBundlePolicyName(d *schema.ResourceData) string {
+	if name := d.Get("policy_name").(string); name != "" {
+		return name
+	}
+	return d.Get("role_name").(string) + "-policy"
+}
+
+func expandRbacBundlePolicy(d *schema.ResourceData) *NewPolicy {
+	return &NewPolicy{
+		Name:       rbacBundlePolicyName(d),
+		Statements: expandStatements(d.Get("statements").([]interface{})),
+	}
+}
+
+func expandRbacBundleRole(d *schema.ResourceData) *NewRole {
+	return &NewRole{
+		Name:        d.Get("role_name").(string),
+		Description: d.Get("role_description").(string),
+	}
+}
+
+// rollback runs the given undo steps in reverse order and reports the ones
+// that failed as warnings, so the user knows which objects were left behind.
+func rollback(undo []func() error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i := len(undo) - 1; i >= 0; i-- {
+		if err := undo[i](); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Rollback incomplete",
+				Detail:   err.Error(),
+			})
+		}
+	}
+	return diags
+}
+
+func resourceKomodorRbacBundleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	var undo []func() error
+
+	fail := func(format string, args ...interface{}) diag.Diagnostics {
+		return append(diag.Errorf(format, args...), rollback(undo)...)
+	}
+
+	newPolicy := expandRbacBundlePolicy(d)
+	policy, err := client.CreatePolicyV2(newPolicy)
+	if err != nil {
+		return fail("Error creating policy: %s", err)
+	}
+	undo = append(undo, func() error {
+		if err := client.DeletePolicyV2(policy.Id); err != nil {
+			return fmt.Errorf("could not delete policy %s: %w", policy.Id, err)
+		}
+		return nil
+	})
+	if err := verifyStatementEffects(newPolicy.Statements, policy); err != nil {
+		return fail("Error creating policy: %s", err)
+	}
+
+	role, err := client.CreateRole(expandRbacBundleRole(d))
+	if err != nil {
+		return fail("Error creating role: %s", err)
+	}
+	undo = append(undo, func() error {
+		if err := client.DeleteRole(role.Id); err != nil {
+			return fmt.Errorf("could not delete role %s: %w", role.Id, err)
+		}
+		return nil
+	})
+
+	if err := client.AttachPolicy(policy.Id, role.Id); err != nil {
+		return fail("Error attaching policy %s to role %s: %s", policy.Id, role.Id, err)
+	}
+	undo = append(undo, func() error {
+		if err := client.DetachPolicy(policy.Id, role.Id); err != nil {
+			return fmt.Errorf("could not detach policy %s from role %s: %w", policy.Id, role.Id, err)
+		}
+		return nil
+	})
+
+	for _, member := range ExpandStringSet(d.Get("members").(*schema.Set)) {
+		userId := *member
+		if err := client.AttachUserToRole(userId, role.Id, ""); err != nil {
+			return fail("Error binding user %s to role %s: %s", userId, role.Id, err)
+		}
+		undo = append(undo, func() error {
+			if err := client.DetachUserFromRole(userId, role.Id); err != nil {
+				return fmt.Errorf("could not unbind user %s from role %s: %w", userId, role.Id, err)
+			}
+			return nil
+		})
+	}
+
+	d.SetId(role.Id)
+	if err := d.Set("policy_id", policy.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] RBAC bundle created successfully. Role Id: %s, Policy Id: %s", role.Id, policy.Id)
+
+	return resourceKomodorRbacBundleRead(ctx, d, meta)
+}
+
+// resourceKomodorRbacBundleCustomizeDiff plans a new policy_id when Read
+// found the policy gone.
+func resourceKomodorRbacBundleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && d.Get("policy_id").(string) == "" {
+		return d.SetNewComputed("policy_id")
+	}
+	return nil
+}
+
+func resourceKomodorRbacBundleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+	policyId := d.Get("policy_id").(string)
+
+	role, statusCode, err := client.GetRole(roleId)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] RBAC bundle role (%s) was not found - removing from state", roleId)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading role: %s", err)
+	}
+
+	// An empty policy_id means an earlier Read already found the policy gone.
+	var policy *Policy
+	if policyId != "" {
+		policy, statusCode, err = client.GetPolicy(policyId)
+		if err != nil {
+			if statusCode != 404 {
+				return diag.Errorf("Error reading policy: %s", err)
+			}
+			// The role is still there, so only the policy is planned for
+			// re-creation: dropping the bundle would orphan the role and its
+			// members.
+			log.Printf("[DEBUG] RBAC bundle policy (%s) was not found - planning its re-creation", policyId)
+			policy = nil
+		} else if !lo.ContainsBy(role.Policies, func(p PolicyRole) bool { return p.Id == policyId }) {
+			log.Printf("[WARN] Policy %s is no longer attached to role %s", policyId, roleId)
+		}
+	}
+
+	members := make([]string, 0)
+	memberUserIds := make(map[string]interface{})
+	for _, member := range ExpandStringSet(d.Get("members").(*schema.Set)) {
+		user, statusCode, err := client.GetUser(*member)
+		if err != nil {
+			if statusCode == 404 {
+				log.Printf("[DEBUG] RBAC bundle member (%s) was not found", *member)
+				continue
+			}
+			return diag.Errorf("Error reading user %s: %s", *member, err)
+		}
+		if lo.ContainsBy(user.Roles, func(r UserRoleResponse) bool { return r.Id == roleId }) {
+			members = append(members, *member)
+			memberUserIds[*member] = user.Id
+		}
+	}
+
+	if err := d.Set("role_id", role.Id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_name", role.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_description", role.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if policy == nil {
+		if err := d.Set("policy_id", ""); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("statements", []interface{}{}); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		if err := d.Set("policy_name", policy.Name); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("statements", flattenStatements(policy.Statements)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("member_user_ids", memberUserIds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKomodorRbacBundleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+	policyId := d.Get("policy_id").(string)
+
+	if d.HasChanges("role_name", "role_description") {
+		if _, err := client.UpdateRole(roleId, expandRbacBundleRole(d)); err != nil {
+			return diag.Errorf("Error updating role: %s", err)
+		}
+	}
+
+	if policyId == "" {
+		// Read found the policy deleted outside Terraform.
+		newPolicy := expandRbacBundlePolicy(d)
+		policy, err := client.CreatePolicyV2(newPolicy)
+		if err != nil {
+			return diag.Errorf("Error creating policy: %s", err)
+		}
+		undo := []func() error{func() error {
+			if err := client.DeletePolicyV2(policy.Id); err != nil {
+				return fmt.Errorf("could not delete policy %s: %w", policy.Id, err)
+			}
+			return nil
+		}}
+		if err := verifyStatementEffects(newPolicy.Statements, policy); err != nil {
+			return append(diag.Errorf("Error creating policy: %s", err), rollback(undo)...)
+		}
+		if err := client.AttachPolicy(policy.Id, roleId); err != nil {
+			return append(diag.Errorf("Error attaching policy %s to role %s: %s", policy.Id, roleId, err), rollback(undo)...)
+		}
+		if err := d.Set("policy_id", policy.Id); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChanges("policy_name", "statements") {
+		newPolicy := expandRbacBundlePolicy(d)
+		policy, err := client.UpdatePolicyV2(policyId, newPolicy)
+		if err != nil {
+			return diag.Errorf("Error updating policy: %s", err)
+		}
+		if err := verifyStatementEffects(newPolicy.Statements, policy); err != nil {
+			oldName, _ := d.GetChange("policy_name")
+			oldStatements, _ := d.GetChange("statements")
+			return append(diag.Errorf("Error updating policy: %s", err), rollback([]func() error{
+				restorePolicy(client, policyId, oldName.(string), oldStatements.([]interface{})),
+			})...)
+		}
+	}
+
+	if d.HasChange("members") {
+		o, n := d.GetChange("members")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		for _, member := range ExpandStringSet(os.Difference(ns)) {
+			if err := client.DetachUserFromRole(*member, roleId); err != nil {
+				return diag.Errorf("Error unbinding user %s from role %s: %s", *member, roleId, err)
+			}
+		}
+		for _, member := range ExpandStringSet(ns.Difference(os)) {
+			if err := client.AttachUserToRole(*member, roleId, ""); err != nil {
+				return diag.Errorf("Error binding user %s to role %s: %s", *member, roleId, err)
+			}
+		}
+	}
+
+	log.Printf("[INFO] RBAC bundle %s successfully updated", roleId)
+	return resourceKomodorRbacBundleRead(ctx, d, meta)
+}
+
+func resourceKomodorRbacBundleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	roleId := d.Id()
+	policyId := d.Get("policy_id").(string)
+
+	log.Printf("[INFO] Deleting RBAC bundle: %s", roleId)
+	for _, member := range ExpandStringSet(d.Get("members").(*schema.Set)) {
+		if statusCode, err := client.detachUserFromRole(*member, roleId); err != nil {
+			// A user deleted outside Terraform takes its binding with it.
+			if statusCode != 404 {
+				return diag.Errorf("Error unbinding user %s from role %s: %s", *member, roleId, err)
+			}
+			log.Printf("[DEBUG] RBAC bundle member (%s) was not found - nothing to unbind", *member)
+		}
+	}
+
+	if policyId != "" {
+		if err := client.DetachPolicy(policyId, roleId); err != nil {
+			return diag.Errorf("Error detaching policy %s from role %s: %s", policyId, roleId, err)
+		}
+	}
+
+	if err := client.DeleteRole(roleId); err != nil {
+		return diag.Errorf("Error deleting role: %s", err)
+	}
+
+	if policyId != "" {
+		if err := client.DeletePolicyV2(policyId); err != nil {
+			return diag.Errorf("Error deleting policy: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,80 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	registerAccTest("komodor_rbac_bundle")
+}
+
+func TestAcc_komodor_rbac_bundle_basic(t *testing.T) {
+	roleName := testResourceName("bundle-role")
+	userEmail := accTestPrefix + "bundle-user@komodor-test.com"
+	resourceAddr := "komodor_rbac_bundle.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRbacBundleDestroyed(roleName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRbacBundleConfig(roleName, userEmail, "view:all"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "role_name", roleName),
+					resource.TestCheckResourceAttr(resourceAddr, "policy_name", roleName+"-policy"),
+					resource.TestCheckResourceAttr(resourceAddr, "members.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "role_id"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "policy_id"),
+					resource.TestCheckResourceAttrPair(resourceAddr, "member_user_ids."+userEmail, "komodor_user.test", "id"),
+				),
+			},
+			{
+				Config: testAccRbacBundleConfig(roleName, userEmail, "manage:monitors"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "statements.0.actions.0", "manage:monitors"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRbacBundleDestroyed(roleName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		role, err := client.GetRoleByName(roleName)
+		if err != nil {
+			return fmt.Errorf("error checking role destruction: %s", err)
+		}
+		if role != nil {
+			return fmt.Errorf("role %q still exists after destroy", roleName)
+		}
+		return nil
+	}
+}
+
+func testAccRbacBundleConfig(roleName, userEmail, action string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Bundle User"
+}
+
+resource "komodor_rbac_bundle" "test" {
+  role_name = %q
+  members   = [komodor_user.test.email]
+
+  statements {
+    actions = [%q]
+    resources_scope {
+      clusters   = ["*"]
+      namespaces = ["*"]
+    }
+  }
+}
+`, userEmail, roleName, action)
+}
@@ -0,0 +1,162 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback_RunsInReverseAndReportsFailures(t *testing.T) {
+	var order []string
+	step := func(name string, err error) func() error {
+		return func() error {
+			order = append(order, name)
+			return err
+		}
+	}
+
+	diags := rollback([]func() error{
+		step("delete policy", nil),
+		step("delete role", errors.New("could not delete role r1")),
+		step("detach policy", nil),
+	})
+
+	assert.Equal(t, []string{"detach policy", "delete role", "delete policy"}, order)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, "r1")
+	}
+}
+
+func TestRollback_NothingToUndo(t *testing.T) {
+	assert.Empty(t, rollback(nil))
+}
+
+func TestRbacBundleCreateRollsBackDroppedDeny(t *testing.T) {
+	var requests []string
+	client := NewClient("key", newEffectlessPolicyServer(t, &requests).URL)
+	r := resourceKomodorRbacBundle()
+
+	config := testDenyPolicyConfig()
+	delete(config, "name")
+	config["role_name"] = "no-deletes"
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	diags := r.CreateContext(context.Background(), d, client)
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, []string{"POST /api/v2/rbac/policies", "DELETE /api/v2/rbac/policies/p-1"}, requests,
+		"the role is never created and the policy is deleted")
+}
+
+func TestRbacBundleReadKeepsRoleWhenPolicyIsGone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/rbac/roles/r-1" {
+			require.NoError(t, json.NewEncoder(w).Encode(Role{Id: "r-1", Name: "no-deletes"}))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("key", server.URL)
+	r := resourceKomodorRbacBundle()
+
+	config := testDenyPolicyConfig()
+	delete(config, "name")
+	config["role_name"] = "no-deletes"
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("r-1")
+	require.NoError(t, d.Set("policy_id", "p-1"))
+	diags := r.ReadContext(context.Background(), d, client)
+
+	require.False(t, diags.HasError())
+	assert.Equal(t, "r-1", d.Id(), "the role still exists")
+	assert.Equal(t, "", d.Get("policy_id"))
+	assert.Empty(t, d.Get("statements").([]interface{}), "the policy is planned for re-creation")
+}
+
+func TestRbacBundleUpdateRecreatesMissingPolicy(t *testing.T) {
+	var requests []string
+	var policy Policy
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v2/rbac/policies":
+			var p NewPolicy
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+			policy = Policy{Id: "p-2", Name: p.Name, Statements: p.Statements}
+			require.NoError(t, json.NewEncoder(w).Encode(policy))
+		case "GET /api/v2/rbac/policies/p-2":
+			require.NoError(t, json.NewEncoder(w).Encode(policy))
+		case "GET /api/v2/rbac/roles/r-1":
+			require.NoError(t, json.NewEncoder(w).Encode(Role{Id: "r-1", Name: "no-deletes", Policies: []PolicyRole{{Id: "p-2"}}}))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("key", server.URL)
+	r := resourceKomodorRbacBundle()
+
+	state := &terraform.InstanceState{
+		ID: "r-1",
+		Attributes: map[string]string{
+			"id":           "r-1",
+			"role_id":      "r-1",
+			"role_name":    "no-deletes",
+			"policy_name":  "no-deletes-policy",
+			"policy_id":    "",
+			"statements.#": "0",
+		},
+	}
+	config := testDenyPolicyConfig()
+	delete(config, "name")
+	config["role_name"] = "no-deletes"
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	require.NoError(t, err)
+	assert.True(t, diff.Attributes["policy_id"].NewComputed)
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	diags := r.UpdateContext(context.Background(), d, client)
+
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "p-2", d.Get("policy_id"))
+	assert.Equal(t, []string{"POST /api/v2/rbac/policies", "POST /api/v2/rbac/roles/policies"}, requests[:2])
+}
+
+func TestRbacBundleDeleteToleratesDeletedMember(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/api/v2/rbac/users/roles" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("key", server.URL)
+	r := resourceKomodorRbacBundle()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"role_name": "no-deletes",
+		"members":   []interface{}{"gone@example.com"},
+	})
+	d.SetId("r-1")
+	require.NoError(t, d.Set("policy_id", "p-1"))
+	diags := r.DeleteContext(context.Background(), d, client)
+
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{
+		"DELETE /api/v2/rbac/users/roles",
+		"DELETE /api/v2/rbac/roles/policies",
+		"DELETE /api/v2/rbac/roles/r-1",
+		"DELETE /api/v2/rbac/policies/p-1",
+	}, requests)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
 )
 
 func resourceKomodorRole() *schema.Resource {
@@ -18,6 +19,19 @@ func resourceKomodorRole() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 				Description:  "The name of the role.",
 			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A human-readable description of the role.",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Free-form key-value metadata attached to the role.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -41,6 +55,8 @@ func resourceKomodorRole() *schema.Resource {
 				Computed:    true,
 				Description: "Whether this is a default built-in role.",
 			},
+
+			"policies": rolePoliciesComputedSchema(),
 		},
 		CreateContext: resourceKomodorRoleCreate,
 		ReadContext:   resourceKomodorRoleRead,
@@ -51,12 +67,54 @@ func resourceKomodorRole() *schema.Resource {
 	}
 }
 
+func rolePoliciesComputedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The policies attached to the role.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the policy.",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the policy.",
+				},
+			},
+		},
+	}
+}
+
+func expandRole(d *schema.ResourceData) *NewRole {
+	metadata := make(map[string]string)
+	for k, v := range d.Get("metadata").(map[string]interface{}) {
+		metadata[k] = v.(string)
+	}
+
+	return &NewRole{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Metadata:    metadata,
+	}
+}
+
+func flattenRolePolicies(policies []PolicyRole) []interface{} {
+	return lo.Map(policies, func(p PolicyRole, _ int) interface{} {
+		return map[string]interface{}{
+			"id":   p.Id,
+			"name": p.Name,
+		}
+	})
+}
+
 func resourceKomodorRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 
-	newRole := &NewRole{
-		Name: d.Get("name").(string),
-	}
+	newRole := expandRole(d)
 
 	log.Printf("[DEBUG] Role create configuration: %#v", newRole)
 	role, err := client.CreateRole(newRole)
@@ -87,6 +145,12 @@ func resourceKomodorRoleRead(ctx context.Context, d *schema.ResourceData, meta i
 	if err := d.Set("name", role.Name); err != nil {
 		return diag.Errorf("error setting name: %s", err)
 	}
+	if err := d.Set("description", role.Description); err != nil {
+		return diag.Errorf("error setting description: %s", err)
+	}
+	if err := d.Set("metadata", role.Metadata); err != nil {
+		return diag.Errorf("error setting metadata: %s", err)
+	}
 	if err := d.Set("created_at", role.CreatedAt); err != nil {
 		return diag.Errorf("error setting created_at: %s", err)
 	}
@@ -96,6 +160,9 @@ func resourceKomodorRoleRead(ctx context.Context, d *schema.ResourceData, meta i
 	if err := d.Set("is_default", role.IsDefault); err != nil {
 		return diag.Errorf("error setting is_default: %s", err)
 	}
+	if err := d.Set("policies", flattenRolePolicies(role.Policies)); err != nil {
+		return diag.Errorf("error setting policies: %s", err)
+	}
 
 	return nil
 }
@@ -103,16 +170,13 @@ func resourceKomodorRoleRead(ctx context.Context, d *schema.ResourceData, meta i
 func resourceKomodorRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	id := d.Id()
-	if d.HasChange("name") {
-		// client.updateRole() // not yet implemented in api, so deleting and recreating
-		if err := client.DeleteRole(id); err != nil {
-			return diag.Errorf("Error deleting Role: %s", err)
-		}
 
-		d.SetId("")
-		return resourceKomodorRoleCreate(ctx, d, meta)
+	if _, err := client.UpdateRole(id, expandRole(d)); err != nil {
+		return diag.Errorf("Error updating Role: %s", err)
 	}
-	return nil
+
+	log.Printf("[INFO] Role %s successfully updated", id)
+	return resourceKomodorRoleRead(ctx, d, meta)
 }
 
 func resourceKomodorRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
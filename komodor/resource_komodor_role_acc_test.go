@@ -16,6 +16,7 @@ func TestAcc_komodor_role_basic(t *testing.T) {
 	name := testResourceName("role")
 	updatedName := name + "-updated"
 	resourceAddr := "komodor_role.test"
+	var roleId string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -28,15 +29,27 @@ func TestAcc_komodor_role_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceAddr, "name", name),
 					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
 					resource.TestCheckResourceAttrSet(resourceAddr, "created_at"),
+					testAccCaptureResourceID(resourceAddr, &roleId),
 				),
 			},
-			// Update: role update is implemented as delete+recreate; the plan
-			// after apply must still show no pending changes.
+			// Update: renaming and describing the role happens in place, so the
+			// role keeps its ID.
 			{
-				Config: testAccRoleConfig(updatedName),
+				Config: testAccRoleConfigWithDescription(updatedName, "Managed by acceptance tests"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceAddr, "name", updatedName),
-					resource.TestCheckResourceAttrSet(resourceAddr, "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "description", "Managed by acceptance tests"),
+					resource.TestCheckResourceAttr(resourceAddr, "metadata.team", "platform"),
+					resource.TestCheckResourceAttrPtr(resourceAddr, "id", &roleId),
+				),
+			},
+			// Removing description and metadata clears them on the server.
+			{
+				Config: testAccRoleConfig(updatedName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "description", ""),
+					resource.TestCheckResourceAttr(resourceAddr, "metadata.%", "0"),
+					resource.TestCheckResourceAttrPtr(resourceAddr, "id", &roleId),
 				),
 			},
 		},
@@ -64,3 +77,28 @@ resource "komodor_role" "test" {
 }
 `, name)
 }
+
+func testAccRoleConfigWithDescription(name, description string) string {
+	return fmt.Sprintf(`
+resource "komodor_role" "test" {
+  name        = %q
+  description = %q
+  metadata = {
+    team = "platform"
+  }
+}
+`, name, description)
+}
+
+// testAccCaptureResourceID stores the ID of the given resource so later steps
+// can assert it was updated in place rather than replaced.
+func testAccCaptureResourceID(resourceAddr string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceAddr]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceAddr)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func resourceKomodorServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Creates a Komodor Service Account, a non-human identity for CI jobs and in-cluster tools. Issue credentials for it with `komodor_api_key`.",
+		CreateContext: resourceKomodorServiceAccountCreate,
+		ReadContext:   resourceKomodorServiceAccountRead,
+		UpdateContext: resourceKomodorServiceAccountUpdate,
+		DeleteContext: resourceKomodorServiceAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the service account.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A description of what the service account is used for.",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of role IDs to bind the service account to. Roles bound outside Terraform show up as drift.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the service account was created.",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the service account was last updated.",
+			},
+		},
+	}
+}
+
+// updateServiceAccountRoles binds the service account to the added roles and
+// unbinds it from the removed ones.
+func (c *Client) updateServiceAccountRoles(id string, add []*string, remove []*string) error {
+	for _, roleId := range remove {
+		if err := c.DetachServiceAccountFromRole(id, *roleId); err != nil {
+			return fmt.Errorf("error detaching role %s from service account %s: %w", *roleId, id, err)
+		}
+	}
+	for _, roleId := range add {
+		if err := c.AttachServiceAccountToRole(id, *roleId); err != nil {
+			return fmt.Errorf("error attaching role %s to service account %s: %w", *roleId, id, err)
+		}
+	}
+	return nil
+}
+
+func resourceKomodorServiceAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	serviceAccount, err := client.CreateServiceAccount(&NewServiceAccount{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	})
+	if err != nil {
+		return diag.Errorf("Error creating Service Account: %s", err)
+	}
+
+	d.SetId(serviceAccount.Id)
+	log.Printf("[INFO] Service Account created successfully. Service Account Id: %s", serviceAccount.Id)
+
+	if err := client.updateServiceAccountRoles(serviceAccount.Id, ExpandStringSet(d.Get("roles").(*schema.Set)), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKomodorServiceAccountRead(ctx, d, meta)
+}
+
+func resourceKomodorServiceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	serviceAccount, statusCode, err := client.GetServiceAccount(id)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Service Account (%s) was not found - removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading Service Account: %s", err)
+	}
+
+	if err := d.Set("name", serviceAccount.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", serviceAccount.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", lo.Map(serviceAccount.Roles, func(r UserRoleResponse, _ int) string { return r.Id })); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("created_at", serviceAccount.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("updated_at", serviceAccount.UpdatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKomodorServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	if d.HasChanges("name", "description") {
+		_, err := client.UpdateServiceAccount(id, &NewServiceAccount{
+			Name:        d.Get("name").(string),
+			Description: d.Get("description").(string),
+		})
+		if err != nil {
+			return diag.Errorf("Error updating Service Account: %s", err)
+		}
+	}
+
+	if d.HasChange("roles") {
+		o, n := d.GetChange("roles")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+		if err := client.updateServiceAccountRoles(id, ExpandStringSet(ns.Difference(os)), ExpandStringSet(os.Difference(ns))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[INFO] Service Account %s successfully updated", id)
+	return resourceKomodorServiceAccountRead(ctx, d, meta)
+}
+
+func resourceKomodorServiceAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	log.Printf("[INFO] Deleting Service Account: %s", id)
+	if err := client.DeleteServiceAccount(id); err != nil {
+		return diag.Errorf("Error deleting Service Account: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,78 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	registerAccTest("komodor_service_account")
+}
+
+func TestAcc_komodor_service_account_basic(t *testing.T) {
+	name := testResourceName("service-account")
+	roleName := testResourceName("sa-role")
+	resourceAddr := "komodor_service_account.test"
+	var serviceAccountId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckServiceAccountDestroyed(&serviceAccountId),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAccountConfig(name, roleName, "CI pipelines", "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureResourceID(resourceAddr, &serviceAccountId),
+					resource.TestCheckResourceAttr(resourceAddr, "name", name),
+					resource.TestCheckResourceAttr(resourceAddr, "description", "CI pipelines"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "0"),
+				),
+			},
+			{
+				Config: testAccServiceAccountConfig(name, roleName, "CI and CD pipelines", "[komodor_role.test.id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "description", "CI and CD pipelines"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "roles.*", "komodor_role.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceAddr,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckServiceAccountDestroyed(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		_, statusCode, err := client.GetServiceAccount(*id)
+		if statusCode == 404 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error checking service account destruction: %s", err)
+		}
+		return fmt.Errorf("service account %q still exists after destroy", *id)
+	}
+}
+
+func testAccServiceAccountConfig(name, roleName, description, roles string) string {
+	return fmt.Sprintf(`
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_service_account" "test" {
+  name        = %q
+  description = %q
+  roles       = %s
+}
+`, roleName, name, description, roles)
+}
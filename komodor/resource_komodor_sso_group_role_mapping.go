@@ -0,0 +1,210 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKomodorSsoGroupRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Maps an SSO (identity provider) group to one or more Komodor Roles. Users who sign in through SSO are granted the roles mapped to each of their groups.",
+		CreateContext: resourceKomodorSsoGroupRoleMappingCreate,
+		ReadContext:   resourceKomodorSsoGroupRoleMappingRead,
+		UpdateContext: resourceKomodorSsoGroupRoleMappingUpdate,
+		DeleteContext: resourceKomodorSsoGroupRoleMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The name of the group, as sent by the identity provider in the SSO assertion.",
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Set of role IDs to grant to the members of the group.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "RFC3339 timestamp at which the mapping expires. Must be in the future when the mapping is applied. Expiry is terminal: once it has passed, the roles are not granted again and the mapping stays in state as last applied, without planning any changes.",
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration of the roles currently mapped to the group, as reported by Komodor. Empty when the mappings do not expire.",
+			},
+			"role_expirations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of role ID to the expiration reported for that mapping. Roles mapped indefinitely are omitted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// flattenSsoGroupRoles returns the IDs of the roles mapped to a group that
+// have not expired, their expirations and the earliest of them.
+func flattenSsoGroupRoles(roles []SsoGroupRole, now time.Time) ([]string, map[string]interface{}, string) {
+	roleIds := make([]string, 0, len(roles))
+	roleExpirations := make(map[string]interface{})
+	earliest := ""
+	var earliestTime time.Time
+	for _, role := range roles {
+		if roleGrantExpired(role.Expiration, now) {
+			continue
+		}
+		roleIds = append(roleIds, role.Id)
+		if role.Expiration == "" {
+			continue
+		}
+		roleExpirations[role.Id] = role.Expiration
+		if t, err := time.Parse(time.RFC3339, role.Expiration); err == nil && (earliest == "" || t.Before(earliestTime)) {
+			earliest, earliestTime = role.Expiration, t
+		}
+	}
+	return roleIds, roleExpirations, earliest
+}
+
+func resourceKomodorSsoGroupRoleMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	groupName := d.Get("group_name").(string)
+
+	expiration, err := resolveGrantExpiration(d.Get("expires_at").(string), "", time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.attachRolesToSsoGroup(groupName, ExpandStringSet(d.Get("roles").(*schema.Set)), expiration); err != nil {
+		return diag.Errorf("Error mapping roles to SSO group: %s", err)
+	}
+
+	d.SetId(groupName)
+	log.Printf("[INFO] SSO group %s mapped to roles", groupName)
+	return resourceKomodorSsoGroupRoleMappingRead(ctx, d, meta)
+}
+
+func resourceKomodorSsoGroupRoleMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	groupName := d.Id()
+
+	now := time.Now()
+
+	// An expired mapping is kept in state as it was last applied, so that
+	// the lapsed roles are neither reported as drift nor removed.
+	if roleGrantExpired(d.Get("expires_at").(string), now) {
+		log.Printf("[DEBUG] SSO group role mapping (%s) expired at %s", groupName, d.Get("expires_at").(string))
+		return nil
+	}
+
+	group, statusCode, err := client.GetSsoGroup(groupName)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] SSO group role mapping (%s) was not found - removing from state", groupName)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading SSO group role mapping: %s", err)
+	}
+
+	roleIds, roleExpirations, earliest := flattenSsoGroupRoles(group.Roles, now)
+	if len(roleIds) == 0 {
+		log.Printf("[DEBUG] SSO group (%s) has no roles mapped - removing from state", groupName)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("group_name", groupName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("roles", roleIds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_expirations", roleExpirations); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration", earliest); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKomodorSsoGroupRoleMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	groupName := d.Id()
+
+	expiration, err := resolveGrantExpiration(d.Get("expires_at").(string), "", time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	o, n := d.GetChange("roles")
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	if d.HasChange("expires_at") {
+		for _, roleId := range ExpandStringSet(os.Intersection(ns)) {
+			if err := client.UpdateSsoGroupRole(groupName, *roleId, expiration); err != nil {
+				return diag.Errorf("Error updating expiration of role %s for SSO group %s: %s", *roleId, groupName, err)
+			}
+		}
+	}
+
+	if d.HasChange("roles") {
+		for _, roleId := range ExpandStringSet(os.Difference(ns)) {
+			if err := client.DetachSsoGroupFromRole(groupName, *roleId); err != nil {
+				return diag.Errorf("Error unmapping role %s from SSO group %s: %s", *roleId, groupName, err)
+			}
+		}
+		if err := client.attachRolesToSsoGroup(groupName, ExpandStringSet(ns.Difference(os)), expiration); err != nil {
+			return diag.Errorf("Error mapping roles to SSO group: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] SSO group role mapping %s successfully updated", groupName)
+	return resourceKomodorSsoGroupRoleMappingRead(ctx, d, meta)
+}
+
+func resourceKomodorSsoGroupRoleMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	groupName := d.Id()
+
+	log.Printf("[INFO] Deleting SSO group role mapping: %s", groupName)
+	for _, roleId := range ExpandStringSet(d.Get("roles").(*schema.Set)) {
+		if err := client.DetachSsoGroupFromRole(groupName, *roleId); err != nil {
+			return diag.Errorf("Error unmapping role %s from SSO group %s: %s", *roleId, groupName, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func (c *Client) attachRolesToSsoGroup(groupName string, roles []*string, expiration string) error {
+	for _, roleId := range roles {
+		if err := c.AttachSsoGroupToRole(groupName, *roleId, expiration); err != nil {
+			return fmt.Errorf("error mapping role %s to SSO group %s: %w", *roleId, groupName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	registerAccTest("komodor_sso_group_role_mapping")
+}
+
+func TestAcc_komodor_sso_group_role_mapping_basic(t *testing.T) {
+	groupName := testResourceName("sso-group")
+	roleName := testResourceName("sso-role")
+	role2Name := testResourceName("sso-role2")
+	resourceAddr := "komodor_sso_group_role_mapping.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSsoGroupRoleMappingDestroyed(groupName),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSsoGroupRoleMappingConfig(groupName, roleName, role2Name, `[komodor_role.test.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "id", groupName),
+					resource.TestCheckResourceAttr(resourceAddr, "group_name", groupName),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "expiration", ""),
+				),
+			},
+			{
+				Config: testAccSsoGroupRoleMappingConfig(groupName, roleName, role2Name, `[komodor_role.test.id, komodor_role.test2.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceAddr,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckSsoGroupRoleMappingDestroyed(groupName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		group, statusCode, err := client.GetSsoGroup(groupName)
+		if statusCode == 404 {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error fetching SSO group %q: %s", groupName, err)
+		}
+		if len(group.Roles) > 0 {
+			return fmt.Errorf("SSO group %q still has %d role(s) mapped after destroy", groupName, len(group.Roles))
+		}
+		return nil
+	}
+}
+
+func testAccSsoGroupRoleMappingConfig(groupName, roleName, role2Name, roles string) string {
+	return fmt.Sprintf(`
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_role" "test2" {
+  name = %q
+}
+
+resource "komodor_sso_group_role_mapping" "test" {
+  group_name = %q
+  roles      = %s
+}
+`, roleName, role2Name, groupName, roles)
+}
@@ -0,0 +1,118 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenSsoGroupRoles(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	roles := []SsoGroupRole{
+		{Id: "role-a"},
+		{Id: "role-b", Expiration: "2026-01-03T00:00:00Z"},
+		{Id: "role-c", Expiration: "2026-01-02T00:00:00Z"},
+		{Id: "role-expired", Expiration: "2025-12-31T00:00:00Z"},
+	}
+
+	roleIds, roleExpirations, earliest := flattenSsoGroupRoles(roles, now)
+	assert.Equal(t, []string{"role-a", "role-b", "role-c"}, roleIds)
+	assert.Equal(t, map[string]interface{}{
+		"role-b": "2026-01-03T00:00:00Z",
+		"role-c": "2026-01-02T00:00:00Z",
+	}, roleExpirations)
+	assert.Equal(t, "2026-01-02T00:00:00Z", earliest)
+}
+
+func TestSsoGroupRoleMappingClient(t *testing.T) {
+	var attached, detached []SsoGroupRoleCreateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v2/rbac/sso/groups/platform%2Fadmins":
+			_ = json.NewEncoder(w).Encode(SsoGroup{GroupName: "platform/admins", Roles: []SsoGroupRole{{Id: "role-a", Name: "admin"}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/rbac/sso/group-roles":
+			var req SsoGroupRoleCreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			attached = append(attached, req)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/rbac/sso/group-roles":
+			var req SsoGroupRoleCreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			detached = append(detached, req)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+
+	group, statusCode, err := client.GetSsoGroup("platform/admins")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, []SsoGroupRole{{Id: "role-a", Name: "admin"}}, group.Roles)
+
+	_, statusCode, err = client.GetSsoGroup("missing")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+
+	roleA, roleB := "role-a", "role-b"
+	require.NoError(t, client.attachRolesToSsoGroup("platform/admins", []*string{&roleA, &roleB}, "2026-01-02T00:00:00Z"))
+	assert.Equal(t, []SsoGroupRoleCreateRequest{
+		{GroupName: "platform/admins", RoleId: "role-a", Expiration: "2026-01-02T00:00:00Z"},
+		{GroupName: "platform/admins", RoleId: "role-b", Expiration: "2026-01-02T00:00:00Z"},
+	}, attached)
+
+	require.NoError(t, client.DetachSsoGroupFromRole("platform/admins", "role-b"))
+	assert.Equal(t, []SsoGroupRoleCreateRequest{{GroupName: "platform/admins", RoleId: "role-b"}}, detached)
+}
+
+func TestUpdateSsoGroupRoleClearsExpiration(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	require.NoError(t, client.UpdateSsoGroupRole("platform-team", "role-1", "2026-03-02T00:00:00Z"))
+	require.NoError(t, client.UpdateSsoGroupRole("platform-team", "role-1", ""))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "2026-03-02T00:00:00Z", bodies[0]["expiration"])
+	value, sent := bodies[1]["expiration"]
+	assert.True(t, sent, "a cleared expiration is sent explicitly")
+	assert.Nil(t, value)
+}
+
+func TestSsoGroupRoleMappingReadKeepsExpiredMapping(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	r := resourceKomodorSsoGroupRoleMapping()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_name": "contractors",
+		"roles":      []interface{}{"role-a"},
+		"expires_at": "2020-01-01T00:00:00Z",
+	})
+	d.SetId("contractors")
+
+	require.False(t, r.ReadContext(context.Background(), d, NewClient("key", server.URL)).HasError())
+	assert.Equal(t, "contractors", d.Id(), "an expired mapping stays in state")
+	assert.Equal(t, 1, d.Get("roles.#"))
+	assert.Zero(t, requests)
+}
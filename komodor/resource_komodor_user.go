@@ -30,6 +30,20 @@ func resourceKomodorUser() *schema.Resource {
 				ForceNew:     true,
 				Description:  "The email address of the user. Changing this forces a new resource to be created.",
 			},
+			"restore_if_deleted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to restore a previously deleted user with the same email instead of failing to create it. Only applies on creation. Defaults to `true`.",
+			},
+			"deletion_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      deletionPolicyDelete,
+				ValidateFunc: validation.StringInSlice([]string{deletionPolicyDelete, deletionPolicyRetain}, false),
+				Description:  "What to do with the user when the resource is destroyed. `delete` deletes the user from Komodor; `retain` only removes it from Terraform state, leaving offboarding to happen outside Terraform. Defaults to `delete`.",
+			},
+			"roles": userRolesComputedSchema(),
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -49,13 +63,18 @@ func resourceKomodorUser() *schema.Resource {
 	}
 }
 
+const (
+	deletionPolicyDelete = "delete"
+	deletionPolicyRetain = "retain"
+)
+
 func resourceKomodorUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 
 	newUser := &NewUser{
 		DisplayName:      d.Get("display_name").(string),
 		Email:            d.Get("email").(string),
-		RestoreIfDeleted: true,
+		RestoreIfDeleted: d.Get("restore_if_deleted").(bool),
 	}
 
 	log.Printf("[DEBUG] User create configuration: %#v", newUser)
@@ -90,6 +109,9 @@ func resourceKomodorUserRead(ctx context.Context, d *schema.ResourceData, meta i
 	if err := d.Set("email", user.Email); err != nil {
 		return diag.Errorf("error setting email: %s", err)
 	}
+	if err := d.Set("roles", flattenUserRoles(user.Roles)); err != nil {
+		return diag.Errorf("error setting roles: %s", err)
+	}
 	if err := d.Set("created_at", user.CreatedAt); err != nil {
 		return diag.Errorf("error setting created_at: %s", err)
 	}
@@ -104,14 +126,18 @@ func resourceKomodorUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 	client := meta.(*Client)
 	id := d.Id()
 
-	updateUser := &UpdateUser{
-		DisplayName: d.Get("display_name").(string),
-	}
+	// restore_if_deleted and deletion_policy only affect creation and
+	// destruction, so changing them alone doesn't call the API.
+	if d.HasChange("display_name") {
+		updateUser := &UpdateUser{
+			DisplayName: d.Get("display_name").(string),
+		}
 
-	_, err := client.UpdateUser(id, updateUser)
+		_, err := client.UpdateUser(id, updateUser)
 
-	if err != nil {
-		return diag.Errorf("Error updating user: %s", err)
+		if err != nil {
+			return diag.Errorf("Error updating user: %s", err)
+		}
 	}
 
 	log.Printf("[INFO] User %s successfully updated", id)
@@ -122,6 +148,12 @@ func resourceKomodorUserDelete(ctx context.Context, d *schema.ResourceData, meta
 	client := meta.(*Client)
 	id := d.Id()
 
+	if d.Get("deletion_policy").(string) == deletionPolicyRetain {
+		log.Printf("[INFO] Retaining User %s in Komodor - removing from state only", id)
+		d.SetId("")
+		return nil
+	}
+
 	log.Printf("[INFO] Deleting User: %s", id)
 	if err := client.DeleteUser(id); err != nil {
 		return diag.Errorf("Error deleting User: %s", err)
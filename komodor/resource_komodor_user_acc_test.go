@@ -42,6 +42,47 @@ func TestAcc_komodor_user_basic(t *testing.T) {
 	})
 }
 
+func TestAcc_komodor_user_retain(t *testing.T) {
+	email := accTestPrefix + "retained-user@komodor-test.com"
+	resourceAddr := "komodor_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserRetained(email),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email              = %q
+  display_name       = "Acc Test Retained User"
+  restore_if_deleted = false
+  deletion_policy    = "retain"
+}
+`, email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "deletion_policy", "retain"),
+					resource.TestCheckResourceAttr(resourceAddr, "restore_if_deleted", "false"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserRetained verifies destroy left the user in place, then
+// deletes it so the test doesn't leak users.
+func testAccCheckUserRetained(email string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		user, _, err := client.GetUser(email)
+		if err != nil {
+			return fmt.Errorf("user %q was not retained after destroy: %s", email, err)
+		}
+		return client.DeleteUser(user.Id)
+	}
+}
+
 func testAccCheckUserDestroyed(email string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*Client)
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
 )
 
 func resourceUserRoleBinding() *schema.Resource {
@@ -30,29 +32,219 @@ func resourceUserRoleBinding() *schema.Resource {
 			"roles": {
 				Type:        schema.TypeSet,
 				Required:    true,
-				Description: "Set of role IDs to assign to the user",
+				MinItems:    1,
+				Description: "Set of roles to assign to the user, each given by ID or as `name:<role name>`.",
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.NoZeroValues,
+					ValidateFunc: validateRoleRef,
 				},
 				Set: schema.HashString,
 			},
+			"role_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The IDs of the roles granted to the user, with `name:` references in `roles` resolved.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"expires_at": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"ttl"},
+				Description:   "RFC3339 timestamp at which the role grants expire. Must be in the future when the grant is applied. Conflicts with `ttl`.",
+			},
+			"ttl": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateGrantTTL,
+				ConflictsWith: []string{"expires_at"},
+				Description:   "Duration after which the role grants expire, counted from the time they are applied (e.g. `8h`, `30m`). Conflicts with `expires_at`.",
+			},
+			"on_expiration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      onExpirationRecreate,
+				ValidateFunc: validation.StringInSlice([]string{onExpirationRecreate, onExpirationDrop}, false),
+				Description:  "What to do once a time-bound grant has expired. `recreate` reports the expired roles as drift so the next apply grants them again; `drop` lets the grant lapse without planning any changes. Defaults to `recreate`.",
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration of the roles currently granted to the user, as reported by Komodor. Empty when the grants do not expire.",
+			},
+			"remaining_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time left until `expiration` at the last refresh (e.g. `7h59m0s`). `0s` once expired, empty when the grants do not expire.",
+			},
+			"role_expirations": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of role ID to the expiration reported for that grant. Roles granted indefinitely are omitted.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		CreateContext: resourceUserRoleBindingCreate,
 		ReadContext:   resourceUserRoleBindingRead,
 		UpdateContext: resourceUserRoleBindingUpdate,
 		DeleteContext: resourceUserRoleBindingDelete,
+		CustomizeDiff: resourceUserRoleBindingCustomizeDiff,
 		Description:   "Creates a binding between a Komodor User and one or more Komodor Roles",
 	}
 }
 
+const (
+	onExpirationRecreate = "recreate"
+	onExpirationDrop     = "drop"
+)
+
+func validateGrantTTL(v interface{}, k string) ([]string, []error) {
+	ttl, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a valid duration (e.g. 8h, 30m): %s", k, err)}
+	}
+	if ttl <= 0 {
+		return nil, []error{fmt.Errorf("%q must be a positive duration, got %s", k, ttl)}
+	}
+	return nil, nil
+}
+
+// resolveGrantExpiration returns the RFC3339 expiration to send when granting
+// roles at the given time, or an empty string for an indefinite grant.
+func resolveGrantExpiration(expiresAt string, ttl string, now time.Time) (string, error) {
+	if expiresAt != "" {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return "", fmt.Errorf("invalid expires_at %q: %w", expiresAt, err)
+		}
+		if !t.After(now) {
+			return "", fmt.Errorf("expires_at %s is in the past; set a future timestamp or use ttl", expiresAt)
+		}
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	if ttl != "" {
+		dur, err := time.ParseDuration(ttl)
+		if err != nil {
+			return "", fmt.Errorf("invalid ttl %q: %w", ttl, err)
+		}
+		return now.Add(dur).UTC().Format(time.RFC3339), nil
+	}
+	return "", nil
+}
+
+// roleGrantExpired reports whether a grant with the given expiration has
+// lapsed. Grants without an expiration, or with one we can't parse, never do.
+func roleGrantExpired(expiration string, now time.Time) bool {
+	if expiration == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		log.Printf("[WARN] Could not parse role expiration %q: %s", expiration, err)
+		return false
+	}
+	return !t.After(now)
+}
+
+// remainingGrantTime formats the time left until expiration, clamped at zero.
+func remainingGrantTime(expiration string, now time.Time) string {
+	if expiration == "" {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return ""
+	}
+	remaining := t.Sub(now).Truncate(time.Second)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining.String()
+}
+
+// resourceUserRoleBindingCustomizeDiff plans the role IDs that the configured
+// roles resolve to.
+func resourceUserRoleBindingCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*Client)
+	if !ok || !d.HasChange("roles") {
+		return nil
+	}
+	if !d.NewValueKnown("roles") {
+		return d.SetNewComputed("role_ids")
+	}
+
+	ids, err := client.resolveRoleRefs(roleRefs(d.Get("roles").(*schema.Set)))
+	if err != nil {
+		// The role may be created by this apply; resolve it then.
+		log.Printf("[DEBUG] Deferring role resolution for User-Role binding %s: %s", d.Id(), err)
+		return d.SetNewComputed("role_ids")
+	}
+	return d.SetNew("role_ids", ids)
+}
+
+func roleRefs(roles *schema.Set) []string {
+	return lo.Map(roles.List(), func(v interface{}, _ int) string { return v.(string) })
+}
+
+// configuredRoleIds resolves the configured roles to role IDs.
+func (c *Client) configuredRoleIds(d *schema.ResourceData) (*schema.Set, error) {
+	ids, err := c.resolveRoleRefs(roleRefs(d.Get("roles").(*schema.Set)))
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewSet(schema.HashString, lo.ToAnySlice(ids)), nil
+}
+
+// flattenRoleRefs returns the role references to store for the granted role
+// IDs: the references in state whose role is still granted, followed by the
+// IDs of granted roles that none of them stands for. Roles granted or revoked
+// outside Terraform thereby show up as drift.
+func flattenRoleRefs(refs []string, resolve func(string) (string, error), granted []string) []string {
+	result := make([]string, 0, len(granted))
+	covered := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		id, err := resolve(ref)
+		if err != nil {
+			log.Printf("[DEBUG] Could not resolve role %s: %s", ref, err)
+			continue
+		}
+		if lo.Contains(granted, id) {
+			result = append(result, ref)
+			covered[id] = true
+		}
+	}
+	for _, id := range granted {
+		if !covered[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func grantExpirationFromConfig(d *schema.ResourceData) (string, error) {
+	return resolveGrantExpiration(d.Get("expires_at").(string), d.Get("ttl").(string), time.Now())
+}
+
 func resourceUserRoleBindingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	name := d.Get("name").(string)
 	userId := d.Get("user_id").(string)
-	roles := ExpandStringSet(d.Get("roles").(*schema.Set))
+	roles, err := client.configuredRoleIds(d)
+	if err != nil {
+		return diag.Errorf("Error resolving roles: %s", err)
+	}
 
-	err := client.attachRolesToUser(userId, roles)
+	expiration, err := grantExpirationFromConfig(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.attachRolesToUser(userId, ExpandStringSet(roles), expiration)
 	if err != nil {
 		return diag.Errorf("Error attaching roles to user: %s", err)
 	}
@@ -75,13 +267,56 @@ func resourceUserRoleBindingRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.Errorf("Error reading User-Role binding: %s", err)
 	}
 
+	now := time.Now()
+	dropExpired := d.Get("on_expiration").(string) == onExpirationDrop
 	roleIds := make([]string, 0, len(userRoles))
+	roleExpirations := make(map[string]interface{})
+	earliest := ""
+	var earliestTime time.Time
 	for _, userRole := range userRoles {
+		if roleGrantExpired(userRole.Expiration, now) && !dropExpired {
+			// Leaving the role out surfaces the lapsed grant as drift, so the
+			// next apply grants it again.
+			log.Printf("[DEBUG] Role %s granted to user %s expired at %s", userRole.RoleId, userId, userRole.Expiration)
+			continue
+		}
 		roleIds = append(roleIds, userRole.RoleId)
+		if userRole.Expiration == "" {
+			continue
+		}
+		roleExpirations[userRole.RoleId] = userRole.Expiration
+		if t, err := time.Parse(time.RFC3339, userRole.Expiration); err == nil && (earliest == "" || t.Before(earliestTime)) {
+			earliest, earliestTime = userRole.Expiration, t
+		}
+	}
+
+	if dropExpired && roleGrantExpired(d.Get("expiration").(string), now) {
+		// The API may stop reporting lapsed grants altogether; keep the
+		// granted roles in state so the expiry doesn't plan a re-grant.
+		for _, roleId := range ExpandStringSet(d.Get("role_ids").(*schema.Set)) {
+			if !lo.Contains(roleIds, *roleId) {
+				roleIds = append(roleIds, *roleId)
+			}
+		}
+		if earliest == "" {
+			earliest = d.Get("expiration").(string)
+		}
 	}
 
 	log.Printf("Roles attached to user %s are: %v", userId, roleIds)
-	if err := d.Set("roles", roleIds); err != nil {
+	if err := d.Set("roles", flattenRoleRefs(roleRefs(d.Get("roles").(*schema.Set)), client.resolveRoleRef, roleIds)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_ids", roleIds); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role_expirations", roleExpirations); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiration", earliest); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("remaining_time", remainingGrantTime(earliest, now)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -92,16 +327,33 @@ func resourceUserRoleBindingUpdate(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*Client)
 	userId := d.Get("user_id").(string)
 
+	roles, err := client.configuredRoleIds(d)
+	if err != nil {
+		return diag.Errorf("Error resolving roles: %s", err)
+	}
+
+	var expiration string
+	if d.HasChanges("roles", "expires_at", "ttl") {
+		if expiration, err = grantExpirationFromConfig(d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("expires_at", "ttl") {
+		o, _ := d.GetChange("role_ids")
+		kept := ExpandStringSet(o.(*schema.Set).Intersection(roles))
+		if err := client.updateUserRolesExpiration(userId, kept, expiration); err != nil {
+			return diag.Errorf("Error updating role expiration for user: %s", err)
+		}
+	}
+
 	if d.HasChange("roles") {
-		o, n := d.GetChange("roles")
+		o, _ := d.GetChange("role_ids")
 		if o == nil {
 			o = new(schema.Set)
 		}
-		if n == nil {
-			n = new(schema.Set)
-		}
 		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
+		ns := roles
 		remove := ExpandStringSet(os.Difference(ns))
 		add := ExpandStringSet(ns.Difference(os))
 
@@ -112,7 +364,7 @@ func resourceUserRoleBindingUpdate(ctx context.Context, d *schema.ResourceData,
 		}
 
 		if len(add) > 0 {
-			if err := client.attachRolesToUser(userId, add); err != nil {
+			if err := client.attachRolesToUser(userId, add, expiration); err != nil {
 				return diag.Errorf("Error attaching roles to user: %s", err)
 			}
 		}
@@ -124,9 +376,16 @@ func resourceUserRoleBindingUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceUserRoleBindingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	userId := d.Get("user_id").(string)
-	roles := ExpandStringSet(d.Get("roles").(*schema.Set))
+	roleIds := d.Get("role_ids").(*schema.Set)
+	if roleIds.Len() == 0 {
+		// State written before role_ids existed, and not refreshed since.
+		var err error
+		if roleIds, err = client.configuredRoleIds(d); err != nil {
+			return diag.Errorf("Error resolving roles: %s", err)
+		}
+	}
 
-	err := client.detachRolesFromUser(userId, roles)
+	err := client.detachRolesFromUser(userId, ExpandStringSet(roleIds))
 	if err != nil {
 		return diag.Errorf("Error detaching roles from user: %s", err)
 	}
@@ -135,9 +394,9 @@ func resourceUserRoleBindingDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func (c *Client) attachRolesToUser(userId string, roles []*string) error {
+func (c *Client) attachRolesToUser(userId string, roles []*string, expiration string) error {
 	for _, roleId := range roles {
-		err := c.AttachUserToRole(userId, *roleId)
+		err := c.AttachUserToRole(userId, *roleId, expiration)
 		if err != nil {
 			return fmt.Errorf("error attaching role %s to user %s: %w", *roleId, userId, err)
 		}
@@ -154,3 +413,13 @@ func (c *Client) detachRolesFromUser(userId string, roles []*string) error {
 	}
 	return nil
 }
+
+func (c *Client) updateUserRolesExpiration(userId string, roles []*string, expiration string) error {
+	for _, roleId := range roles {
+		err := c.UpdateUserRole(userId, *roleId, expiration)
+		if err != nil {
+			return fmt.Errorf("error updating expiration of role %s for user %s: %w", *roleId, userId, err)
+		}
+	}
+	return nil
+}
@@ -40,6 +40,28 @@ func TestAcc_komodor_user_role_binding_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "2"),
 				),
 			},
+			// Step 3: Refer to a role by name; role_ids keeps the same IDs
+			{
+				Config: testAccUserRoleBindingConfigRoleNames(userEmail, roleName, role2Name, bindingName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceAddr, "roles.*", "name:"+roleName),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "roles.*", "komodor_role.test2", "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "role_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "role_ids.*", "komodor_role.test", "id"),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "role_ids.*", "komodor_role.test2", "id"),
+				),
+			},
+			// Step 4: Make the grants time-bound
+			{
+				Config: testAccUserRoleBindingConfigTTL(userEmail, roleName, bindingName, "8h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "ttl", "8h"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "expiration"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "remaining_time"),
+				),
+			},
 		},
 	})
 }
@@ -106,3 +128,46 @@ resource "komodor_user_role_binding" "test" {
 }
 `, userEmail, roleName, role2Name, bindingName)
 }
+
+func testAccUserRoleBindingConfigRoleNames(userEmail, roleName, role2Name, bindingName string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Binding User"
+}
+
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_role" "test2" {
+  name = %q
+}
+
+resource "komodor_user_role_binding" "test" {
+  name    = %q
+  user_id = komodor_user.test.id
+  roles   = ["name:${komodor_role.test.name}", komodor_role.test2.id]
+}
+`, userEmail, roleName, role2Name, bindingName)
+}
+
+func testAccUserRoleBindingConfigTTL(userEmail, roleName, bindingName, ttl string) string {
+	return fmt.Sprintf(`
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Binding User"
+}
+
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_user_role_binding" "test" {
+  name    = %q
+  user_id = komodor_user.test.id
+  roles   = [komodor_role.test.id]
+  ttl     = %q
+}
+`, userEmail, roleName, bindingName, ttl)
+}
@@ -0,0 +1,170 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGrantExpiration(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt string
+		ttl       string
+		want      string
+		wantErr   string
+	}{
+		{name: "no expiration grants indefinitely", want: ""},
+		{name: "expires_at in the future", expiresAt: "2026-03-02T00:00:00Z", want: "2026-03-02T00:00:00Z"},
+		{name: "expires_at is normalized to UTC", expiresAt: "2026-03-02T02:00:00+02:00", want: "2026-03-02T00:00:00Z"},
+		{name: "expires_at in the past is rejected", expiresAt: "2026-02-28T00:00:00Z", wantErr: "in the past"},
+		{name: "expires_at equal to now is rejected", expiresAt: "2026-03-01T12:00:00Z", wantErr: "in the past"},
+		{name: "ttl is counted from now", ttl: "8h", want: "2026-03-01T20:00:00Z"},
+		{name: "invalid ttl", ttl: "soon", wantErr: "invalid ttl"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveGrantExpiration(tc.expiresAt, tc.ttl, now)
+			if tc.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.wantErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRoleGrantExpired(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, roleGrantExpired("", now), "indefinite grants never expire")
+	assert.False(t, roleGrantExpired("not-a-date", now), "unparseable expirations are not treated as expired")
+	assert.False(t, roleGrantExpired("2026-03-01T12:00:01Z", now))
+	assert.True(t, roleGrantExpired("2026-03-01T12:00:00Z", now))
+	assert.True(t, roleGrantExpired("2026-02-01T00:00:00Z", now))
+}
+
+func TestRemainingGrantTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "", remainingGrantTime("", now))
+	assert.Equal(t, "1h30m0s", remainingGrantTime("2026-03-01T13:30:00Z", now))
+	assert.Equal(t, "0s", remainingGrantTime("2026-03-01T11:00:00Z", now))
+}
+
+func TestValidateGrantTTL(t *testing.T) {
+	_, errs := validateGrantTTL("8h", "ttl")
+	assert.Empty(t, errs)
+
+	_, errs = validateGrantTTL("0s", "ttl")
+	assert.Len(t, errs, 1)
+
+	_, errs = validateGrantTTL("eight hours", "ttl")
+	assert.Len(t, errs, 1)
+}
+
+func TestUserRoleBindingRoleNamesPlanRoleIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]Role{{Id: "role-1", Name: "viewer"}, {Id: "role-2", Name: "admin"}})
+	}))
+	defer server.Close()
+	client := NewClient("key", server.URL)
+
+	r := resourceUserRoleBinding()
+	state := &terraform.InstanceState{
+		ID: "binding",
+		Attributes: map[string]string{
+			"id":                 "binding",
+			"name":               "binding",
+			"user_id":            "user@example.com",
+			"on_expiration":      onExpirationRecreate,
+			"expiration":         "",
+			"remaining_time":     "",
+			"role_expirations.%": "0",
+			"roles.#":            "2",
+			fmt.Sprintf("roles.%d", schema.HashString("role-1")): "role-1",
+			fmt.Sprintf("roles.%d", schema.HashString("role-2")): "role-2",
+			"role_ids.#": "2",
+			fmt.Sprintf("role_ids.%d", schema.HashString("role-1")): "role-1",
+			fmt.Sprintf("role_ids.%d", schema.HashString("role-2")): "role-2",
+		},
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "binding",
+		"user_id": "user@example.com",
+		"roles":   []interface{}{"name:viewer", "role-2"},
+	})
+	diff, err := r.SimpleDiff(context.Background(), state, config, client)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "name:viewer", diff.Attributes[fmt.Sprintf("roles.%d", schema.HashString("name:viewer"))].New,
+		"roles keeps the configured references")
+	for k := range diff.Attributes {
+		assert.NotContains(t, k, "role_ids", "a name resolving to the ID in state changes no role_ids")
+	}
+
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "binding",
+		"user_id": "user@example.com",
+		"roles":   []interface{}{"name:viewer"},
+	})
+	diff, err = r.SimpleDiff(context.Background(), state, config, client)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "1", diff.Attributes["role_ids.#"].New)
+}
+
+func TestFlattenRoleRefs(t *testing.T) {
+	resolve := func(ref string) (string, error) {
+		switch ref {
+		case "name:viewer":
+			return "role-1", nil
+		case "name:deleted":
+			return "", fmt.Errorf("role %q not found", "deleted")
+		}
+		return ref, nil
+	}
+
+	assert.Equal(t, []string{"name:viewer", "role-2"},
+		flattenRoleRefs([]string{"name:viewer", "role-2"}, resolve, []string{"role-2", "role-1"}))
+	assert.Equal(t, []string{"role-2", "role-3"},
+		flattenRoleRefs([]string{"name:viewer", "role-2", "name:deleted"}, resolve, []string{"role-2", "role-3"}),
+		"revoked and unresolvable roles are dropped, roles granted outside Terraform are added by ID")
+	assert.Equal(t, []string{"role-1"}, flattenRoleRefs(nil, resolve, []string{"role-1"}), "import")
+}
+
+func TestUpdateUserRoleClearsExpiration(t *testing.T) {
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	require.NoError(t, client.UpdateUserRole("user-1", "role-1", "2026-03-02T00:00:00Z"))
+	require.NoError(t, client.UpdateUserRole("user-1", "role-1", ""))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, "2026-03-02T00:00:00Z", bodies[0]["expiration"])
+	value, sent := bodies[1]["expiration"]
+	assert.True(t, sent, "a cleared expiration is sent explicitly")
+	assert.Nil(t, value)
+}
@@ -0,0 +1,190 @@
+package komodor
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var emailKeyRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+$`)
+
+func resourceKomodorUserSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a set of Komodor Users from a single map of email to display name.\n\n" +
+			"Users added to the map are created, users removed from it are deleted, and users whose display name changes are updated in place. " +
+			"Only users created by this resource are managed; users that exist in Komodor but are not in the map are left alone.",
+		CreateContext: resourceKomodorUserSetCreate,
+		ReadContext:   resourceKomodorUserSetRead,
+		UpdateContext: resourceKomodorUserSetUpdate,
+		DeleteContext: resourceKomodorUserSetDelete,
+
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				ValidateDiagFunc: validation.MapKeyMatch(emailKeyRegexp, "keys must be email addresses"),
+				Description:      "Map of user email to display name.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"user_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of user email to the ID Komodor assigned to the user.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// sortedEmails returns the keys of a users map in a stable order, so that
+// users are created and deleted in the same order on every run.
+func sortedEmails(users map[string]interface{}) []string {
+	emails := make([]string, 0, len(users))
+	for email := range users {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+// createUserSetMembers creates the given users and records their IDs in
+// userIds. It stops at the first failure, leaving userIds with the users that
+// were created so far.
+func (c *Client) createUserSetMembers(users map[string]interface{}, userIds map[string]interface{}) error {
+	for _, email := range sortedEmails(users) {
+		user, err := c.CreateUser(&NewUser{
+			DisplayName:      users[email].(string),
+			Email:            email,
+			RestoreIfDeleted: true,
+		})
+		if err != nil {
+			return err
+		}
+		userIds[email] = user.Id
+	}
+	return nil
+}
+
+func resourceKomodorUserSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	users := d.Get("users").(map[string]interface{})
+
+	userIds := make(map[string]interface{})
+	err := client.createUserSetMembers(users, userIds)
+	// Keep the users created so far in state even on failure, so they are
+	// deleted when the tainted resource is replaced rather than leaked.
+	d.SetId(id.UniqueId())
+	if setErr := d.Set("user_ids", userIds); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+	if err != nil {
+		return diag.Errorf("Error creating users: %s", err)
+	}
+	log.Printf("[INFO] User set created with %d user(s). Id: %s", len(userIds), d.Id())
+
+	return resourceKomodorUserSetRead(ctx, d, meta)
+}
+
+func resourceKomodorUserSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	userIds := d.Get("user_ids").(map[string]interface{})
+
+	users := make(map[string]interface{}, len(userIds))
+	found := make(map[string]interface{}, len(userIds))
+	for email, userId := range userIds {
+		user, statusCode, err := client.GetUser(userId.(string))
+		if err != nil {
+			if statusCode == 404 {
+				log.Printf("[DEBUG] User %s (%s) was not found - removing from user set", email, userId)
+				continue
+			}
+			return diag.Errorf("Error reading user %s: %s", email, err)
+		}
+		users[email] = user.DisplayName
+		found[email] = user.Id
+	}
+
+	if err := d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_ids", found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKomodorUserSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	o, n := d.GetChange("users")
+	oldUsers := o.(map[string]interface{})
+	newUsers := n.(map[string]interface{})
+	userIds := d.Get("user_ids").(map[string]interface{})
+
+	for _, email := range sortedEmails(oldUsers) {
+		userId, ok := userIds[email]
+		if !ok {
+			continue
+		}
+		if _, keep := newUsers[email]; keep {
+			continue
+		}
+		log.Printf("[INFO] Deleting user %s (%s) from user set %s", email, userId, d.Id())
+		if err := client.DeleteUser(userId.(string)); err != nil {
+			return diag.Errorf("Error deleting user %s: %s", email, err)
+		}
+		delete(userIds, email)
+	}
+
+	added := make(map[string]interface{})
+	for _, email := range sortedEmails(newUsers) {
+		userId, ok := userIds[email]
+		if !ok {
+			added[email] = newUsers[email]
+			continue
+		}
+		if oldUsers[email] == newUsers[email] {
+			continue
+		}
+		if _, err := client.UpdateUser(userId.(string), &UpdateUser{DisplayName: newUsers[email].(string)}); err != nil {
+			return diag.Errorf("Error updating user %s: %s", email, err)
+		}
+	}
+
+	err := client.createUserSetMembers(added, userIds)
+	if setErr := d.Set("user_ids", userIds); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+	if err != nil {
+		return diag.Errorf("Error creating users: %s", err)
+	}
+
+	log.Printf("[INFO] User set %s successfully updated", d.Id())
+	return resourceKomodorUserSetRead(ctx, d, meta)
+}
+
+func resourceKomodorUserSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	userIds := d.Get("user_ids").(map[string]interface{})
+
+	log.Printf("[INFO] Deleting user set: %s", d.Id())
+	for _, email := range sortedEmails(userIds) {
+		if err := client.DeleteUser(userIds[email].(string)); err != nil {
+			return diag.Errorf("Error deleting user %s: %s", email, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,77 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/samber/lo"
+)
+
+func init() {
+	registerAccTest("komodor_user_set")
+}
+
+func TestAcc_komodor_user_set_basic(t *testing.T) {
+	alice := accTestPrefix + "set-alice@komodor-test.com"
+	bob := accTestPrefix + "set-bob@komodor-test.com"
+	carol := accTestPrefix + "set-carol@komodor-test.com"
+	resourceAddr := "komodor_user_set.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserSetDestroyed(alice, bob, carol),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserSetConfig(map[string]string{alice: "Alice", bob: "Bob"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "users.%", "2"),
+					resource.TestCheckResourceAttr(resourceAddr, "user_ids.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceAddr, "user_ids."+alice),
+				),
+			},
+			// Rename one user, remove another and add a third
+			{
+				Config: testAccUserSetConfig(map[string]string{alice: "Alice Renamed", carol: "Carol"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "users.%", "2"),
+					resource.TestCheckResourceAttr(resourceAddr, "users."+alice, "Alice Renamed"),
+					resource.TestCheckNoResourceAttr(resourceAddr, "user_ids."+bob),
+					resource.TestCheckResourceAttrSet(resourceAddr, "user_ids."+carol),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckUserSetDestroyed(emails ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client)
+		for _, email := range emails {
+			_, statusCode, err := client.GetUser(email)
+			if statusCode == 404 {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("error fetching user %q: %s", email, err)
+			}
+			return fmt.Errorf("user %q still exists after destroy", email)
+		}
+		return nil
+	}
+}
+
+func testAccUserSetConfig(users map[string]string) string {
+	entries := ""
+	for _, email := range sortedEmails(lo.MapValues(users, func(v string, _ string) interface{} { return v })) {
+		entries += fmt.Sprintf("    %q = %q\n", email, users[email])
+	}
+	return fmt.Sprintf(`
+resource "komodor_user_set" "test" {
+  users = {
+%s  }
+}
+`, entries)
+}
@@ -0,0 +1,54 @@
+package komodor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserSetMembers(t *testing.T) {
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/users" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req NewUser
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Email == "broken@example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		created = append(created, req.Email)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(User{Id: "id-" + req.Email, Email: req.Email, DisplayName: req.DisplayName})
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+
+	userIds := map[string]interface{}{}
+	err := client.createUserSetMembers(map[string]interface{}{
+		"bob@example.com":   "Bob",
+		"alice@example.com": "Alice",
+	}, userIds)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, created)
+	assert.Equal(t, map[string]interface{}{
+		"alice@example.com": "id-alice@example.com",
+		"bob@example.com":   "id-bob@example.com",
+	}, userIds)
+
+	partial := map[string]interface{}{}
+	err = client.createUserSetMembers(map[string]interface{}{
+		"a@example.com":      "A",
+		"broken@example.com": "Broken",
+		"c@example.com":      "C",
+	}, partial)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]interface{}{"a@example.com": "id-a@example.com"}, partial)
+}
@@ -17,6 +17,7 @@ func resourceKomodorWorkspace() *schema.Resource {
 		ReadContext:   resourceKomodorWorkspaceRead,
 		UpdateContext: resourceKomodorWorkspaceUpdate,
 		DeleteContext: resourceKomodorWorkspaceDelete,
+		CustomizeDiff: resourceKomodorWorkspaceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -33,10 +34,18 @@ func resourceKomodorWorkspace() *schema.Resource {
 				Optional:    true,
 				Description: "A human-readable description of the workspace.",
 			},
+			"kind": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      WorkspaceKindScopes,
+				ValidateFunc: validation.StringInSlice([]string{WorkspaceKindScopes, WorkspaceKindClusterGroup, WorkspaceKindLabelSelector}, false),
+				Description:  "How the workspace selects resources: `scopes`, `cluster_group` or `label_selector`. Set the block of the same name. Changing the kind recreates the workspace.",
+			},
 			"scopes": {
 				Type:        schema.TypeList,
-				Required:    true,
-				Description: "One or more scopes defining the Kubernetes resources visible in this workspace.",
+				Optional:    true,
+				Description: "One or more scopes defining the Kubernetes resources visible in this workspace. Required when `kind` is `scopes`.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"clusters": {
@@ -70,6 +79,43 @@ func resourceKomodorWorkspace() *schema.Resource {
 					},
 				},
 			},
+			"cluster_group": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The clusters whose resources are visible in this workspace. Required when `kind` is `cluster_group`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"clusters": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "List of cluster names to include.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"clusters_patterns": patternListSchema(0),
+					},
+				},
+			},
+			"label_selector": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Selects the clusters whose resources are visible in this workspace by their labels. Required when `kind` is `label_selector`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"match_labels": {
+							Type:        schema.TypeMap,
+							Required:    true,
+							Description: "Cluster labels that must all match for a cluster to be included.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
 			"id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -102,21 +148,76 @@ func resourceKomodorWorkspace() *schema.Resource {
 // Expand (from TF -> GO)
 
 func expandWorkspace(d *schema.ResourceData) *NewWorkspace {
-	scopes := d.Get("scopes").([]interface{})
-	expandedScopes := lo.Map(scopes, func(item interface{}, _ int) ResourcesScope {
-		data := item.(map[string]interface{})
+	return expandWorkspaceFields(
+		d.Get("name").(string),
+		d.Get("description").(string),
+		d.Get("kind").(string),
+		d.Get("scopes").([]interface{}),
+		d.Get("cluster_group").([]interface{}),
+		d.Get("label_selector").([]interface{}),
+	)
+}
+
+// expandWorkspaceFields builds the request from the raw attribute values so
+// that CustomizeDiff can share it with expandWorkspace. The kind is left out
+// of the request for scopes workspaces, which is what the API assumes anyway.
+func expandWorkspaceFields(name, description, kind string, scopes, clusterGroup, labelSelector []interface{}) *NewWorkspace {
+	workspace := &NewWorkspace{
+		Name:          name,
+		Description:   description,
+		Scopes:        expandWorkspaceScopes(scopes),
+		ClusterGroup:  expandWorkspaceClusterGroup(clusterGroup),
+		LabelSelector: expandWorkspaceLabelSelector(labelSelector),
+	}
+	if kind != WorkspaceKindScopes {
+		workspace.Kind = kind
+	}
+	return workspace
+}
+
+func expandWorkspaceScopes(scopes []interface{}) []ResourcesScope {
+	return lo.Map(scopes, func(item interface{}, _ int) ResourcesScope {
+		// An empty scope block is null; CustomizeDiff rejects it.
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			return ResourcesScope{}
+		}
 		scope := expandResourcesScope([]interface{}{data})
 		if scope == nil {
 			return ResourcesScope{}
 		}
 		return *scope
 	})
+}
 
-	return &NewWorkspace{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		Scopes:      expandedScopes,
+func expandWorkspaceClusterGroup(list []interface{}) *WorkspaceClusterGroup {
+	if len(list) == 0 {
+		return nil
 	}
+	group := &WorkspaceClusterGroup{Clusters: []string{}, ClustersPatterns: []Pattern{}}
+	// An empty cluster_group block is null; CustomizeDiff rejects it.
+	data, ok := list[0].(map[string]interface{})
+	if !ok {
+		return group
+	}
+	group.Clusters = toStringList(data["clusters"].([]interface{}))
+	group.ClustersPatterns = expandPatterns(data["clusters_patterns"].([]interface{}))
+	return group
+}
+
+func expandWorkspaceLabelSelector(list []interface{}) *WorkspaceLabelSelector {
+	if len(list) == 0 {
+		return nil
+	}
+	selector := &WorkspaceLabelSelector{MatchLabels: map[string]string{}}
+	data, ok := list[0].(map[string]interface{})
+	if !ok {
+		return selector
+	}
+	for k, v := range data["match_labels"].(map[string]interface{}) {
+		selector.MatchLabels[k] = v.(string)
+	}
+	return selector
 }
 
 // Flatten (from GO -> TF)
@@ -128,12 +229,29 @@ func flattenWorkspace(workspace *Workspace, d *schema.ResourceData) error {
 	if err := d.Set("description", workspace.Description); err != nil {
 		return err
 	}
+	kind := workspace.EffectiveKind()
+	switch kind {
+	case WorkspaceKindScopes, WorkspaceKindClusterGroup, WorkspaceKindLabelSelector:
+	default:
+		// Keep reading workspaces of kinds this provider does not know yet, so
+		// that import and data sources work; their definition is not managed.
+		log.Printf("[WARN] Workspace (%s) has unsupported kind %q - only its common attributes are read", workspace.Id, kind)
+	}
+	if err := d.Set("kind", kind); err != nil {
+		return err
+	}
 	scopesList := lo.Map(workspace.Scopes, func(scope ResourcesScope, _ int) interface{} {
 		return flattenResourcesScope(&scope)
 	})
 	if err := d.Set("scopes", scopesList); err != nil {
 		return err
 	}
+	if err := d.Set("cluster_group", flattenWorkspaceClusterGroup(workspace.ClusterGroup)); err != nil {
+		return err
+	}
+	if err := d.Set("label_selector", flattenWorkspaceLabelSelector(workspace.LabelSelector)); err != nil {
+		return err
+	}
 	if err := d.Set("created_at", workspace.CreatedAt); err != nil {
 		return err
 	}
@@ -149,6 +267,29 @@ func flattenWorkspace(workspace *Workspace, d *schema.ResourceData) error {
 	return nil
 }
 
+func flattenWorkspaceClusterGroup(group *WorkspaceClusterGroup) []interface{} {
+	if group == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"clusters":          toInterfaceList(group.Clusters),
+		"clusters_patterns": flattenPatterns(group.ClustersPatterns),
+	}}
+}
+
+func flattenWorkspaceLabelSelector(selector *WorkspaceLabelSelector) []interface{} {
+	if selector == nil {
+		return []interface{}{}
+	}
+	matchLabels := make(map[string]interface{}, len(selector.MatchLabels))
+	for k, v := range selector.MatchLabels {
+		matchLabels[k] = v
+	}
+	return []interface{}{map[string]interface{}{
+		"match_labels": matchLabels,
+	}}
+}
+
 func resourceKomodorWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 
@@ -163,7 +304,7 @@ func resourceKomodorWorkspaceCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[INFO] Workspace created successfully. Workspace Id: %s", workspace.Id)
 
-	return resourceKomodorWorkspaceRead(ctx, d, meta)
+	return append(resourceKomodorWorkspaceRead(ctx, d, meta), client.workspaceClusterWarnings(newWorkspace)...)
 }
 
 func resourceKomodorWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -196,7 +337,11 @@ func resourceKomodorWorkspaceUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	log.Printf("[INFO] Workspace %s successfully updated", d.Id())
-	return resourceKomodorWorkspaceRead(ctx, d, meta)
+	diags := resourceKomodorWorkspaceRead(ctx, d, meta)
+	if d.HasChanges("scopes", "cluster_group") {
+		diags = append(diags, client.workspaceClusterWarnings(newWorkspace)...)
+	}
+	return diags
 }
 
 func resourceKomodorWorkspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
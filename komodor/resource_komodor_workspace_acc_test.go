@@ -2,6 +2,7 @@ package komodor
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -22,6 +23,17 @@ func TestAcc_komodor_workspace_basic(t *testing.T) {
 		Providers:    testAccProviders,
 		CheckDestroy: testAccCheckWorkspaceDestroyed(name),
 		Steps: []resource.TestStep{
+			// Step 0: An empty scope is rejected at plan time
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+  scopes {}
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Empty scope`),
+			},
 			// Step 1: Create with specific clusters/namespaces
 			{
 				Config: testAccWorkspaceConfig(name),
@@ -103,3 +115,76 @@ resource "komodor_workspace" "test" {
 }
 `, name)
 }
+
+func TestAcc_komodor_workspace_kinds(t *testing.T) {
+	name := testResourceName("workspace-kinds")
+	resourceAddr := "komodor_workspace.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWorkspaceDestroyed(name),
+		Steps: []resource.TestStep{
+			// Step 0: A kind without its block is rejected at plan time
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+  kind = "cluster_group"
+}
+`, name),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing cluster_group`),
+			},
+			// Step 1: Create a cluster_group workspace
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+  kind = "cluster_group"
+
+  cluster_group {
+    clusters = ["tf-acc-cluster-1"]
+    clusters_patterns {
+      include = "tf-acc-*"
+      exclude = ""
+    }
+  }
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "kind", "cluster_group"),
+					resource.TestCheckResourceAttr(resourceAddr, "cluster_group.0.clusters.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "cluster_group.0.clusters_patterns.0.include", "tf-acc-*"),
+					resource.TestCheckResourceAttr(resourceAddr, "scopes.#", "0"),
+				),
+			},
+			// Step 2: Import
+			{
+				ResourceName:      resourceAddr,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Step 3: Changing the kind replaces the workspace
+			{
+				Config: fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+  kind = "label_selector"
+
+  label_selector {
+    match_labels = {
+      env = "tf-acc"
+    }
+  }
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "kind", "label_selector"),
+					resource.TestCheckResourceAttr(resourceAddr, "label_selector.0.match_labels.env", "tf-acc"),
+					resource.TestCheckResourceAttr(resourceAddr, "cluster_group.#", "0"),
+				),
+			},
+		},
+	})
+}
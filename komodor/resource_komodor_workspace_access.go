@@ -0,0 +1,172 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/samber/lo"
+)
+
+func resourceKomodorWorkspaceAccess() *schema.Resource {
+	return &schema.Resource{
+		Description: "Shares a Komodor Workspace with users and roles, and makes it the default workspace of roles.\n\n" +
+			"The resource manages the complete access list of the workspace: users and roles it was shared with outside Terraform show up as drift. " +
+			"Destroying it stops sharing the workspace with anyone.",
+		CreateContext: resourceKomodorWorkspaceAccessCreate,
+		ReadContext:   resourceKomodorWorkspaceAccessRead,
+		UpdateContext: resourceKomodorWorkspaceAccessUpdate,
+		DeleteContext: resourceKomodorWorkspaceAccessDelete,
+		CustomizeDiff: resourceKomodorWorkspaceAccessCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "The ID of the workspace to share.",
+			},
+			"users": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"users", "roles"},
+				Description:  "Set of user IDs to share the workspace with.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"roles": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				AtLeastOneOf: []string{"users", "roles"},
+				Description:  "Set of role IDs to share the workspace with. Every member of the role gets access.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+			"default_for_roles": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Set of role IDs, all of which must also be in `roles`, for which this is the default workspace: the one their members see when they sign in. A role has at most one default workspace.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceKomodorWorkspaceAccessCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("roles") || !d.NewValueKnown("default_for_roles") {
+		return nil
+	}
+	roles := d.Get("roles").(*schema.Set)
+	for _, roleId := range d.Get("default_for_roles").(*schema.Set).List() {
+		if !roles.Contains(roleId) {
+			return fmt.Errorf("default_for_roles: role %q must also be listed in roles", roleId)
+		}
+	}
+	return nil
+}
+
+// Expand (from TF -> GO)
+
+func expandWorkspaceAccess(d *schema.ResourceData) *WorkspaceAccess {
+	defaults := d.Get("default_for_roles").(*schema.Set)
+	return &WorkspaceAccess{
+		Users: toStringList(d.Get("users").(*schema.Set).List()),
+		Roles: lo.Map(d.Get("roles").(*schema.Set).List(), func(roleId interface{}, _ int) WorkspaceRoleAccess {
+			return WorkspaceRoleAccess{RoleId: roleId.(string), IsDefault: defaults.Contains(roleId)}
+		}),
+	}
+}
+
+// Flatten (from GO -> TF)
+
+func flattenWorkspaceAccess(access *WorkspaceAccess, d *schema.ResourceData) error {
+	if err := d.Set("users", access.Users); err != nil {
+		return err
+	}
+	if err := d.Set("roles", lo.Map(access.Roles, func(r WorkspaceRoleAccess, _ int) string { return r.RoleId })); err != nil {
+		return err
+	}
+	defaults := lo.FilterMap(access.Roles, func(r WorkspaceRoleAccess, _ int) (string, bool) { return r.RoleId, r.IsDefault })
+	if err := d.Set("default_for_roles", defaults); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceKomodorWorkspaceAccessCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	workspaceId := d.Get("workspace_id").(string)
+
+	if err := client.SetWorkspaceAccess(workspaceId, expandWorkspaceAccess(d)); err != nil {
+		return diag.Errorf("Error sharing workspace: %s", err)
+	}
+
+	d.SetId(workspaceId)
+	log.Printf("[INFO] Workspace %s shared successfully", workspaceId)
+
+	return resourceKomodorWorkspaceAccessRead(ctx, d, meta)
+}
+
+func resourceKomodorWorkspaceAccessRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	access, statusCode, err := client.GetWorkspaceAccess(d.Id())
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Workspace (%s) was not found - removing access from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Error reading workspace access: %s", err)
+	}
+
+	if err := d.Set("workspace_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := flattenWorkspaceAccess(access, d); err != nil {
+		return diag.Errorf("Error flattening workspace access: %s", err)
+	}
+
+	return nil
+}
+
+func resourceKomodorWorkspaceAccessUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+
+	if err := client.SetWorkspaceAccess(d.Id(), expandWorkspaceAccess(d)); err != nil {
+		return diag.Errorf("Error updating workspace access: %s", err)
+	}
+
+	log.Printf("[INFO] Workspace access %s successfully updated", d.Id())
+	return resourceKomodorWorkspaceAccessRead(ctx, d, meta)
+}
+
+func resourceKomodorWorkspaceAccessDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client)
+	id := d.Id()
+
+	log.Printf("[INFO] Revoking access to Workspace: %s", id)
+	if err := client.RevokeWorkspaceAccess(id); err != nil {
+		return diag.Errorf("Error revoking workspace access: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,76 @@
+package komodor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func init() {
+	registerAccTest("komodor_workspace_access")
+}
+
+func TestAcc_komodor_workspace_access_basic(t *testing.T) {
+	workspaceName := testResourceName("access-workspace")
+	roleName := testResourceName("access-role")
+	email := accTestPrefix + "workspace-access@komodor-test.com"
+	resourceAddr := "komodor_workspace_access.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceAccessConfig(workspaceName, roleName, email, "[]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceAddr, "workspace_id", "komodor_workspace.test", "id"),
+					resource.TestCheckResourceAttr(resourceAddr, "users.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "roles.#", "1"),
+					resource.TestCheckResourceAttr(resourceAddr, "default_for_roles.#", "0"),
+				),
+			},
+			// Make it the role's default workspace
+			{
+				Config: testAccWorkspaceAccessConfig(workspaceName, roleName, email, "[komodor_role.test.id]"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceAddr, "default_for_roles.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceAddr, "default_for_roles.*", "komodor_role.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceAddr,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccWorkspaceAccessConfig(workspaceName, roleName, email, defaultForRoles string) string {
+	return fmt.Sprintf(`
+resource "komodor_workspace" "test" {
+  name = %q
+
+  scopes {
+    clusters = ["tf-acc-cluster-1"]
+  }
+}
+
+resource "komodor_role" "test" {
+  name = %q
+}
+
+resource "komodor_user" "test" {
+  email        = %q
+  display_name = "Acc Test Workspace Access"
+}
+
+resource "komodor_workspace_access" "test" {
+  workspace_id      = komodor_workspace.test.id
+  users             = [komodor_user.test.id]
+  roles             = [komodor_role.test.id]
+  default_for_roles = %s
+}
+`, workspaceName, roleName, email, defaultForRoles)
+}
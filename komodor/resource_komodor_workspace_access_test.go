@@ -0,0 +1,73 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceAccessLifecycle(t *testing.T) {
+	var stored *WorkspaceAccess
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/workspaces/ws-1/access" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored = &WorkspaceAccess{}
+			_ = json.NewDecoder(r.Body).Decode(stored)
+			_ = json.NewEncoder(w).Encode(stored)
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(stored)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	ctx := context.Background()
+	r := resourceKomodorWorkspaceAccess()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"workspace_id":      "ws-1",
+		"users":             []interface{}{"user-1"},
+		"roles":             []interface{}{"role-1", "role-2"},
+		"default_for_roles": []interface{}{"role-2"},
+	})
+
+	require.False(t, r.CreateContext(ctx, d, client).HasError())
+	assert.Equal(t, "ws-1", d.Id())
+	assert.Equal(t, []string{"user-1"}, stored.Users)
+	assert.ElementsMatch(t, []WorkspaceRoleAccess{{RoleId: "role-1"}, {RoleId: "role-2", IsDefault: true}}, stored.Roles)
+	assert.Equal(t, []interface{}{"role-2"}, d.Get("default_for_roles").(*schema.Set).List())
+
+	require.False(t, r.DeleteContext(ctx, d, client).HasError())
+	assert.Empty(t, stored.Users)
+	assert.Empty(t, stored.Roles)
+
+	d.SetId("ws-gone")
+	require.False(t, r.DeleteContext(ctx, d, client).HasError(), "revoking access to a deleted workspace succeeds")
+
+	d.SetId("ws-gone")
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	assert.Equal(t, "", d.Id(), "access to a deleted workspace is removed from state")
+}
+
+func TestWorkspaceAccessDefaultRoleMustBeShared(t *testing.T) {
+	r := resourceKomodorWorkspaceAccess()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id":      "ws-1",
+		"roles":             []interface{}{"role-1"},
+		"default_for_roles": []interface{}{"role-2"},
+	})
+
+	_, err := r.SimpleDiff(context.Background(), &terraform.InstanceState{}, config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `role "role-2" must also be listed in roles`)
+}
@@ -0,0 +1,316 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkspaceConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "team-a",
+		"description": "Team A workloads",
+		"scopes": []interface{}{
+			map[string]interface{}{
+				"clusters":   []interface{}{"prod-eu", "prod-us"},
+				"namespaces": []interface{}{"team-a"},
+			},
+			map[string]interface{}{
+				"clusters_patterns":   []interface{}{map[string]interface{}{"include": "staging-*", "exclude": "staging-old"}},
+				"namespaces_patterns": []interface{}{map[string]interface{}{"include": "team-a-*", "exclude": ""}},
+				"selectors": []interface{}{
+					map[string]interface{}{"key": "team", "type": "label", "value": "a"},
+				},
+				"selectors_patterns": []interface{}{
+					map[string]interface{}{
+						"key":   "owner",
+						"type":  "annotation",
+						"value": []interface{}{map[string]interface{}{"include": "team-a*", "exclude": ""}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWorkspaceExpandFlattenRoundTrip(t *testing.T) {
+	r := resourceKomodorWorkspace()
+	d := schema.TestResourceDataRaw(t, r.Schema, testWorkspaceConfig())
+
+	expanded := expandWorkspace(d)
+	assert.Equal(t, "team-a", expanded.Name)
+	assert.Equal(t, "Team A workloads", expanded.Description)
+	require.Len(t, expanded.Scopes, 2)
+	assert.Equal(t, []string{"prod-eu", "prod-us"}, expanded.Scopes[0].Clusters)
+	assert.Equal(t, []Pattern{{Include: "staging-*", Exclude: "staging-old"}}, expanded.Scopes[1].ClustersPatterns)
+	assert.Equal(t, []Selector{{Key: "team", Type: "label", Value: "a"}}, expanded.Scopes[1].Selectors)
+	assert.Equal(t, "team-a*", expanded.Scopes[1].SelectorsPatterns[0].Value.Include)
+
+	workspace := &Workspace{
+		Id:                 "ws-1",
+		Name:               expanded.Name,
+		Description:        expanded.Description,
+		Scopes:             expanded.Scopes,
+		AuthorEmail:        "author@example.com",
+		LastUpdatedByEmail: "editor@example.com",
+		CreatedAt:          "2026-01-01T00:00:00Z",
+		LastUpdated:        "2026-01-02T00:00:00Z",
+	}
+	flattened := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	require.NoError(t, flattenWorkspace(workspace, flattened))
+
+	assert.Equal(t, expanded, expandWorkspace(flattened))
+	assert.Equal(t, "author@example.com", flattened.Get("author_email"))
+	assert.Equal(t, "editor@example.com", flattened.Get("last_updated_by_email"))
+	assert.Equal(t, "2026-01-02T00:00:00Z", flattened.Get("updated_at"))
+}
+
+func TestAnalyzeWorkspaceScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		scopes    []ResourcesScope
+		summaries []string
+	}{
+		{
+			name:   "valid scopes",
+			scopes: []ResourcesScope{{Clusters: []string{"prod"}}, {NamespacesPatterns: []Pattern{{Include: "team-*"}}}},
+		},
+		{
+			name:      "empty scope",
+			scopes:    []ResourcesScope{{Clusters: []string{"prod"}}, {}},
+			summaries: []string{"Empty scope"},
+		},
+		{
+			name:      "invalid pattern",
+			scopes:    []ResourcesScope{{ClustersPatterns: []Pattern{{Include: "prod-["}}}},
+			summaries: []string{"Invalid pattern"},
+		},
+		{
+			name:      "exclude cancels include",
+			scopes:    []ResourcesScope{{NamespacesPatterns: []Pattern{{Include: "team-*", Exclude: "*"}}}},
+			summaries: []string{"Pattern matches nothing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var summaries []string
+			for _, f := range analyzeWorkspaceScopes(tt.scopes) {
+				summaries = append(summaries, f.Summary)
+			}
+			assert.Equal(t, tt.summaries, summaries)
+		})
+	}
+}
+
+func TestWorkspaceCustomizeDiffRejectsEmptyScope(t *testing.T) {
+	r := resourceKomodorWorkspace()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":   "team-a",
+		"scopes": []interface{}{map[string]interface{}{}},
+	})
+
+	_, err := r.SimpleDiff(context.Background(), &terraform.InstanceState{}, config, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "scopes[0]: Empty scope")
+}
+
+func TestWorkspaceClusterWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/integrations/kubernetes/prod":
+			_, _ = w.Write([]byte(`{"apiKey":"key"}`))
+		case "/api/v2/integrations/kubernetes/flaky":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	diags := client.workspaceClusterWarnings(&NewWorkspace{Scopes: []ResourcesScope{
+		{Clusters: []string{"prod", "missing"}},
+		{Clusters: []string{"missing", "flaky"}},
+	}})
+
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Cluster not onboarded", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `"missing" in scopes[0].clusters[1]`)
+
+	diags = client.workspaceClusterWarnings(&NewWorkspace{
+		Kind:         WorkspaceKindClusterGroup,
+		ClusterGroup: &WorkspaceClusterGroup{Clusters: []string{"prod", "gone"}},
+	})
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Detail, `"gone" in cluster_group[0].clusters[1]`)
+}
+
+func TestWorkspaceKindsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected *NewWorkspace
+	}{
+		{
+			name: "cluster group",
+			config: map[string]interface{}{
+				"name": "prod",
+				"kind": WorkspaceKindClusterGroup,
+				"cluster_group": []interface{}{map[string]interface{}{
+					"clusters":          []interface{}{"prod-eu"},
+					"clusters_patterns": []interface{}{map[string]interface{}{"include": "prod-*", "exclude": "prod-old"}},
+				}},
+			},
+			expected: &NewWorkspace{
+				Name:   "prod",
+				Kind:   WorkspaceKindClusterGroup,
+				Scopes: []ResourcesScope{},
+				ClusterGroup: &WorkspaceClusterGroup{
+					Clusters:         []string{"prod-eu"},
+					ClustersPatterns: []Pattern{{Include: "prod-*", Exclude: "prod-old"}},
+				},
+			},
+		},
+		{
+			name: "label selector",
+			config: map[string]interface{}{
+				"name": "eu",
+				"kind": WorkspaceKindLabelSelector,
+				"label_selector": []interface{}{map[string]interface{}{
+					"match_labels": map[string]interface{}{"region": "eu", "env": "prod"},
+				}},
+			},
+			expected: &NewWorkspace{
+				Name:          "eu",
+				Kind:          WorkspaceKindLabelSelector,
+				Scopes:        []ResourcesScope{},
+				LabelSelector: &WorkspaceLabelSelector{MatchLabels: map[string]string{"region": "eu", "env": "prod"}},
+			},
+		},
+	}
+
+	r := resourceKomodorWorkspace()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := expandWorkspace(schema.TestResourceDataRaw(t, r.Schema, tt.config))
+			assert.Equal(t, tt.expected, expanded)
+
+			flattened := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+			require.NoError(t, flattenWorkspace(&Workspace{
+				Id:            "ws-1",
+				Name:          expanded.Name,
+				Kind:          expanded.Kind,
+				Scopes:        expanded.Scopes,
+				ClusterGroup:  expanded.ClusterGroup,
+				LabelSelector: expanded.LabelSelector,
+			}, flattened))
+			assert.Equal(t, expanded, expandWorkspace(flattened))
+		})
+	}
+}
+
+func TestFlattenWorkspaceUnknownKind(t *testing.T) {
+	var workspace Workspace
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "ws-1",
+		"name": "future",
+		"kind": "service_graph",
+		"scopes": null,
+		"serviceGraph": {"roots": ["checkout"]}
+	}`), &workspace))
+
+	for _, r := range []*schema.Resource{resourceKomodorWorkspace(), dataSourceKomodorWorkspace()} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		require.NoError(t, flattenWorkspace(&workspace, d))
+		assert.Equal(t, "service_graph", d.Get("kind"))
+		assert.Equal(t, "future", d.Get("name"))
+		assert.Empty(t, d.Get("scopes"))
+		assert.Empty(t, d.Get("cluster_group"))
+	}
+}
+
+func TestFlattenWorkspaceWithoutKindIsScopes(t *testing.T) {
+	r := resourceKomodorWorkspace()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	require.NoError(t, flattenWorkspace(&Workspace{Id: "ws-1", Scopes: []ResourcesScope{{Clusters: []string{"prod"}}}}, d))
+	assert.Equal(t, WorkspaceKindScopes, d.Get("kind"))
+	assert.Empty(t, expandWorkspace(d).Kind)
+}
+
+func TestWorkspaceCustomizeDiffKinds(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		errors []string
+	}{
+		{
+			name:   "scopes kind without scopes",
+			config: map[string]interface{}{"name": "ws"},
+			errors: []string{"scopes: Missing scopes"},
+		},
+		{
+			name: "cluster group with scopes",
+			config: map[string]interface{}{
+				"name":          "ws",
+				"kind":          WorkspaceKindClusterGroup,
+				"scopes":        []interface{}{map[string]interface{}{"clusters": []interface{}{"prod"}}},
+				"cluster_group": []interface{}{map[string]interface{}{"clusters": []interface{}{"prod"}}},
+			},
+			errors: []string{"scopes: Unexpected scopes"},
+		},
+		{
+			name:   "empty cluster group",
+			config: map[string]interface{}{"name": "ws", "kind": WorkspaceKindClusterGroup, "cluster_group": []interface{}{map[string]interface{}{}}},
+			errors: []string{"cluster_group[0]: Empty cluster_group"},
+		},
+		{
+			name: "invalid cluster group pattern",
+			config: map[string]interface{}{
+				"name": "ws",
+				"kind": WorkspaceKindClusterGroup,
+				"cluster_group": []interface{}{map[string]interface{}{
+					"clusters_patterns": []interface{}{map[string]interface{}{"include": "prod-[", "exclude": ""}},
+				}},
+			},
+			errors: []string{"cluster_group[0].clusters_patterns[0]: Invalid pattern"},
+		},
+		{
+			name:   "label selector without block",
+			config: map[string]interface{}{"name": "ws", "kind": WorkspaceKindLabelSelector},
+			errors: []string{"label_selector: Missing label_selector"},
+		},
+		{
+			name: "valid label selector",
+			config: map[string]interface{}{
+				"name":           "ws",
+				"kind":           WorkspaceKindLabelSelector,
+				"label_selector": []interface{}{map[string]interface{}{"match_labels": map[string]interface{}{"env": "prod"}}},
+			},
+		},
+	}
+
+	r := resourceKomodorWorkspace()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.SimpleDiff(context.Background(), &terraform.InstanceState{}, terraform.NewResourceConfigRaw(tt.config), nil)
+			if len(tt.errors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, e := range tt.errors {
+				assert.Contains(t, err.Error(), e)
+			}
+		})
+	}
+}
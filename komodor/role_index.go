@@ -0,0 +1,109 @@
+package komodor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// roleNamePrefix marks a role reference that names the role instead of
+// giving its ID, e.g. `name:viewer`.
+const roleNamePrefix = "name:"
+
+// roleIndex caches the mapping of role names to IDs. A client lives for a
+// single Terraform operation, so the roles are listed at most once per plan
+// or apply, plus once more whenever a name is missing from the cache.
+type roleIndex struct {
+	mu     sync.Mutex
+	byName map[string]string
+}
+
+func (i *roleIndex) invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.byName = nil
+}
+
+func (c *Client) loadRoleIndex() error {
+	roles, err := c.GetRoles()
+	if err != nil {
+		return err
+	}
+	byName := make(map[string]string, len(roles))
+	for _, role := range roles {
+		byName[role.Name] = role.Id
+	}
+	c.roleIndex.byName = byName
+	return nil
+}
+
+// lookupRoleId returns the ID of the role with the given name. The roles are
+// listed again on a cache miss, in case the role was created after the index
+// was built.
+func (c *Client) lookupRoleId(name string) (string, bool, error) {
+	c.roleIndex.mu.Lock()
+	defer c.roleIndex.mu.Unlock()
+
+	fresh := false
+	if c.roleIndex.byName == nil {
+		if err := c.loadRoleIndex(); err != nil {
+			return "", false, err
+		}
+		fresh = true
+	}
+	if id, ok := c.roleIndex.byName[name]; ok {
+		return id, true, nil
+	}
+	if fresh {
+		return "", false, nil
+	}
+	if err := c.loadRoleIndex(); err != nil {
+		return "", false, err
+	}
+	id, ok := c.roleIndex.byName[name]
+	return id, ok, nil
+}
+
+// isRoleNameRef reports whether a role reference is a `name:<role name>`
+// reference rather than a role ID.
+func isRoleNameRef(ref string) bool {
+	return strings.HasPrefix(ref, roleNamePrefix)
+}
+
+// resolveRoleRef returns the ID a role reference stands for. IDs are
+// returned as they are, without checking that the role exists.
+func (c *Client) resolveRoleRef(ref string) (string, error) {
+	if !isRoleNameRef(ref) {
+		return ref, nil
+	}
+	name := strings.TrimPrefix(ref, roleNamePrefix)
+	id, found, err := c.lookupRoleId(name)
+	if err != nil {
+		return "", fmt.Errorf("error listing roles to resolve %q: %w", ref, err)
+	}
+	if !found {
+		return "", fmt.Errorf("role %q not found", name)
+	}
+	return id, nil
+}
+
+// resolveRoleRefs resolves role references to canonical role IDs.
+func (c *Client) resolveRoleRefs(refs []string) ([]string, error) {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := c.resolveRoleRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func validateRoleRef(v interface{}, k string) ([]string, []error) {
+	ref := v.(string)
+	if ref == "" || ref == roleNamePrefix {
+		return nil, []error{fmt.Errorf("%q must be a role ID or %s<role name>, got %q", k, roleNamePrefix, ref)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,71 @@
+package komodor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRoleRefs(t *testing.T) {
+	roles := []Role{{Id: "role-1", Name: "viewer"}, {Id: "role-2", Name: "admin"}}
+	listCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/rbac/roles":
+			listCalls++
+			_ = json.NewEncoder(w).Encode(roles)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/rbac/roles":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Role{Id: "role-4", Name: "auditor"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+
+	ids, err := client.resolveRoleRefs([]string{"name:viewer", "role-9", "name:admin"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"role-1", "role-9", "role-2"}, ids)
+	assert.Equal(t, 1, listCalls, "names are resolved from a single listing")
+
+	_, err = client.resolveRoleRefs([]string{"name:viewer"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, listCalls, "the index is reused across lookups")
+
+	// A role created outside the client is picked up on a cache miss.
+	roles = append(roles, Role{Id: "role-3", Name: "oncall"})
+	ids, err = client.resolveRoleRefs([]string{"name:oncall"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"role-3"}, ids)
+	assert.Equal(t, 2, listCalls)
+
+	_, err = client.resolveRoleRefs([]string{"name:missing"})
+	assert.EqualError(t, err, `role "missing" not found`)
+	assert.Equal(t, 3, listCalls)
+
+	// Creating a role through the client drops the index.
+	_, err = client.CreateRole(&NewRole{Name: "auditor"})
+	require.NoError(t, err)
+	roles = append(roles, Role{Id: "role-4", Name: "auditor"})
+	ids, err = client.resolveRoleRefs([]string{"name:auditor"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"role-4"}, ids)
+	assert.Equal(t, 4, listCalls)
+}
+
+func TestValidateRoleRef(t *testing.T) {
+	for _, ref := range []string{"role-1", "name:viewer"} {
+		_, errs := validateRoleRef(ref, "roles")
+		assert.Empty(t, errs, ref)
+	}
+	for _, ref := range []string{"", "name:"} {
+		_, errs := validateRoleRef(ref, "roles")
+		assert.Len(t, errs, 1, ref)
+	}
+}
@@ -12,16 +12,29 @@ type PolicyRole struct {
 }
 
 type Role struct {
-	Id        string       `json:"id"`
-	Name      string       `json:"name"`
-	CreatedAt string       `json:"createdAt"`
-	UpdatedAt string       `json:"updatedAt"`
-	IsDefault bool         `json:"isDefault"`
-	Policies  []PolicyRole `json:"policies"`
+	Id          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	CreatedAt   string            `json:"createdAt"`
+	UpdatedAt   string            `json:"updatedAt"`
+	IsDefault   bool              `json:"isDefault"`
+	Policies    []PolicyRole      `json:"policies"`
 }
 
 type NewRole struct {
-	Name string `json:"name"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// roleUpdateRequest is the body of a role update. Unlike NewRole it always
+// carries description and metadata, so that clearing them in config clears
+// them on the server.
+type roleUpdateRequest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Metadata    map[string]string `json:"metadata"`
 }
 
 func (c *Client) GetRoles() ([]Role, error) {
@@ -80,6 +93,7 @@ func (c *Client) CreateRole(role *NewRole) (*Role, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.roleIndex.invalidate()
 	res, _, err := c.executeHttpRequest(http.MethodPost, c.GetRolesUrl(), &requestBody)
 
 	if err != nil {
@@ -95,7 +109,33 @@ func (c *Client) CreateRole(role *NewRole) (*Role, error) {
 	return &newRole, nil
 }
 
+func (c *Client) UpdateRole(id string, role *NewRole) (*Role, error) {
+	update := roleUpdateRequest{Name: role.Name, Description: role.Description, Metadata: role.Metadata}
+	if update.Metadata == nil {
+		update.Metadata = map[string]string{}
+	}
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	c.roleIndex.invalidate()
+	res, _, err := c.executeHttpRequest(http.MethodPut, fmt.Sprintf("%s/%s", c.GetRolesUrl(), id), &requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedRole Role
+	err = json.Unmarshal(res, &updatedRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedRole, nil
+}
+
 func (c *Client) DeleteRole(id string) error {
+	c.roleIndex.invalidate()
 	_, _, err := c.executeHttpRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.GetRolesUrl(), id), nil)
 	if err != nil {
 		return err
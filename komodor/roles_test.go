@@ -0,0 +1,30 @@
+package komodor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRoleSendsClearedFields(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"id":"role-1","name":"viewer"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", server.URL)
+	_, err := client.UpdateRole("role-1", &NewRole{Name: "viewer"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"name":        "viewer",
+		"description": "",
+		"metadata":    map[string]interface{}{},
+	}, body)
+}
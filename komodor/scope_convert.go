@@ -0,0 +1,73 @@
+package komodor
+
+import (
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/samber/lo"
+)
+
+func toScopePatterns(patterns []Pattern) []scope.Pattern {
+	return lo.Map(patterns, func(p Pattern, _ int) scope.Pattern {
+		return scope.Pattern{Include: p.Include, Exclude: p.Exclude}
+	})
+}
+
+// toScope converts an RBAC or workspace scope for local evaluation.
+func (s *ResourcesScope) toScope() scope.Scope {
+	return scope.Scope{
+		Clusters:   scope.Dimension{Names: s.Clusters, Patterns: toScopePatterns(s.ClustersPatterns)},
+		Namespaces: scope.Dimension{Names: s.Namespaces, Patterns: toScopePatterns(s.NamespacesPatterns)},
+		Selectors: lo.Map(s.Selectors, func(sel Selector, _ int) scope.Selector {
+			return scope.Selector{Key: sel.Key, Type: string(sel.Type), Value: sel.Value}
+		}),
+		SelectorPatterns: lo.Map(s.SelectorsPatterns, func(sp SelectorPattern, _ int) scope.SelectorPattern {
+			return scope.SelectorPattern{
+				Key:   sp.Key,
+				Type:  string(sp.Type),
+				Value: scope.Pattern{Include: sp.Value.Include, Exclude: sp.Value.Exclude},
+			}
+		}),
+	}
+}
+
+func policyDimension(names *[]string, pattern *PolicyPattern) scope.Dimension {
+	dim := scope.Dimension{}
+	if names != nil {
+		dim.Names = *names
+	}
+	if pattern != nil {
+		dim.Patterns = []scope.Pattern{{
+			Include: lo.FromPtr(pattern.Include),
+			Exclude: lo.FromPtr(pattern.Exclude),
+		}}
+	}
+	return dim
+}
+
+// toScope converts a right-sizing policy scope for local evaluation.
+func (s PolicyResourceScope) toScope() scope.Scope {
+	return scope.Scope{
+		Clusters:      policyDimension(s.Clusters, s.ClustersPatterns),
+		Namespaces:    policyDimension(s.Namespaces, s.NamespacesPatterns),
+		Workloads:     policyDimension(s.Workloads, s.WorkloadsPatterns),
+		ResourceTypes: policyDimension(s.ResourceTypes, s.ResourceTypesPatterns),
+	}
+}
+
+// matchResourcesScope reports whether the queried resource falls within an
+// RBAC scope. A statement without a scope grants nothing.
+func matchResourcesScope(s *ResourcesScope, q scope.Query) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	return s.toScope().Matches(q)
+}
+
+// containsResourcesScope reports whether an RBAC scope covers everything the
+// query describes. Deny statements are evaluated with it, so that denying a
+// namespace does not deny the whole cluster.
+func containsResourcesScope(s *ResourcesScope, q scope.Query) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	return s.toScope().Contains(q)
+}
@@ -0,0 +1,49 @@
+package komodor
+
+import (
+	"testing"
+
+	"github.com/komodorio/terraform-provider-komodor/internal/scope"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourcesScopeToScope(t *testing.T) {
+	s := &ResourcesScope{
+		Clusters:           []string{"prod-us"},
+		NamespacesPatterns: []Pattern{{Include: "team-*", Exclude: "team-internal"}},
+		Selectors:          []Selector{{Key: "env", Type: "label", Value: "production"}},
+		SelectorsPatterns:  []SelectorPattern{{Key: "owner", Type: "annotation", Value: Pattern{Include: "team-*"}}},
+	}
+
+	assert.Equal(t, scope.Scope{
+		Clusters:         scope.Dimension{Names: []string{"prod-us"}, Patterns: []scope.Pattern{}},
+		Namespaces:       scope.Dimension{Patterns: []scope.Pattern{{Include: "team-*", Exclude: "team-internal"}}},
+		Selectors:        []scope.Selector{{Key: "env", Type: "label", Value: "production"}},
+		SelectorPatterns: []scope.SelectorPattern{{Key: "owner", Type: "annotation", Value: scope.Pattern{Include: "team-*"}}},
+	}, s.toScope())
+}
+
+func TestPolicyResourceScopeToScope(t *testing.T) {
+	s := PolicyResourceScope{
+		Clusters:           &[]string{"prod-us"},
+		NamespacesPatterns: &PolicyPattern{Include: lo.ToPtr("*"), Exclude: lo.ToPtr("kube-*")},
+		WorkloadsPatterns:  &PolicyPattern{Include: lo.ToPtr("api-*")},
+	}
+
+	converted := s.toScope()
+	assert.Equal(t, scope.Dimension{Names: []string{"prod-us"}}, converted.Clusters)
+	assert.Equal(t, scope.Dimension{Patterns: []scope.Pattern{{Include: "*", Exclude: "kube-*"}}}, converted.Namespaces)
+	assert.Equal(t, scope.Dimension{Patterns: []scope.Pattern{{Include: "api-*"}}}, converted.Workloads)
+	assert.True(t, converted.ResourceTypes.IsEmpty())
+
+	ok, err := converted.Matches(scope.Query{Cluster: "prod-us", Namespace: "kube-system"})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMatchResourcesScope_NilScope(t *testing.T) {
+	ok, err := matchResourcesScope(nil, scope.Query{Cluster: "any"})
+	assert.NoError(t, err)
+	assert.False(t, ok, "a statement without a scope grants nothing")
+}
@@ -0,0 +1,158 @@
+package komodor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ServiceAccount struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   string             `json:"createdAt"`
+	UpdatedAt   string             `json:"updatedAt"`
+	Roles       []UserRoleResponse `json:"roles"`
+}
+
+type NewServiceAccount struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type ServiceAccountRoleRequest struct {
+	RoleId string `json:"roleId"`
+}
+
+// ApiKey is an API key issued to a service account. Key is only populated in
+// the response to CreateApiKey; Komodor never returns it again.
+type ApiKey struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	ServiceAccountId string `json:"serviceAccountId"`
+	Key              string `json:"key,omitempty"`
+	KeyPrefix        string `json:"keyPrefix"`
+	CreatedAt        string `json:"createdAt"`
+	LastUsedAt       string `json:"lastUsedAt,omitempty"`
+}
+
+type NewApiKey struct {
+	Name             string `json:"name"`
+	ServiceAccountId string `json:"serviceAccountId"`
+}
+
+func (c *Client) GetServiceAccount(id string) (*ServiceAccount, int, error) {
+	var serviceAccount ServiceAccount
+	res, statusCode, err := c.executeHttpRequest(http.MethodGet, fmt.Sprintf("%s/%s", c.GetServiceAccountsUrl(), id), nil)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	err = json.Unmarshal(res, &serviceAccount)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &serviceAccount, statusCode, nil
+}
+
+func (c *Client) CreateServiceAccount(serviceAccount *NewServiceAccount) (*ServiceAccount, error) {
+	requestBody, err := json.Marshal(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := c.executeHttpRequest(http.MethodPost, c.GetServiceAccountsUrl(), &requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var newServiceAccount ServiceAccount
+	err = json.Unmarshal(res, &newServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newServiceAccount, nil
+}
+
+func (c *Client) UpdateServiceAccount(id string, serviceAccount *NewServiceAccount) (*ServiceAccount, error) {
+	requestBody, err := json.Marshal(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := c.executeHttpRequest(http.MethodPut, fmt.Sprintf("%s/%s", c.GetServiceAccountsUrl(), id), &requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated ServiceAccount
+	err = json.Unmarshal(res, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteServiceAccount(id string) error {
+	_, _, err := c.executeHttpRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.GetServiceAccountsUrl(), id), nil)
+	return err
+}
+
+// AttachServiceAccountToRole binds a service account to a role
+func (c *Client) AttachServiceAccountToRole(id string, roleId string) error {
+	requestBody, err := json.Marshal(ServiceAccountRoleRequest{RoleId: roleId})
+	if err != nil {
+		return err
+	}
+	_, _, err = c.executeHttpRequest(http.MethodPost, fmt.Sprintf("%s/%s/roles", c.GetServiceAccountsUrl(), id), &requestBody)
+	return err
+}
+
+// DetachServiceAccountFromRole unbinds a service account from a role
+func (c *Client) DetachServiceAccountFromRole(id string, roleId string) error {
+	_, _, err := c.executeHttpRequest(http.MethodDelete, fmt.Sprintf("%s/%s/roles/%s", c.GetServiceAccountsUrl(), id, roleId), nil)
+	return err
+}
+
+func (c *Client) GetApiKey(id string) (*ApiKey, int, error) {
+	var apiKey ApiKey
+	res, statusCode, err := c.executeHttpRequest(http.MethodGet, fmt.Sprintf("%s/%s", c.GetApiKeysUrl(), id), nil)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	err = json.Unmarshal(res, &apiKey)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return &apiKey, statusCode, nil
+}
+
+func (c *Client) CreateApiKey(apiKey *NewApiKey) (*ApiKey, error) {
+	requestBody, err := json.Marshal(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := c.executeHttpRequest(http.MethodPost, c.GetApiKeysUrl(), &requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var newApiKey ApiKey
+	err = json.Unmarshal(res, &newApiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newApiKey, nil
+}
+
+func (c *Client) DeleteApiKey(id string) error {
+	_, _, err := c.executeHttpRequest(http.MethodDelete, fmt.Sprintf("%s/%s", c.GetApiKeysUrl(), id), nil)
+	return err
+}
@@ -0,0 +1,211 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServiceAccountsApi is an in-memory stand-in for the service account and
+// API key endpoints.
+type fakeServiceAccountsApi struct {
+	mu              sync.Mutex
+	serviceAccounts map[string]*ServiceAccount
+	apiKeys         map[string]*ApiKey
+}
+
+func newFakeServiceAccountsServer(t *testing.T) (*fakeServiceAccountsApi, *Client) {
+	api := &fakeServiceAccountsApi{
+		serviceAccounts: map[string]*ServiceAccount{},
+		apiKeys:         map[string]*ApiKey{},
+	}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, NewClient("key", server.URL)
+}
+
+func (f *fakeServiceAccountsApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/")
+	switch {
+	case parts[0] == "service-accounts" && len(parts) == 1 && r.Method == http.MethodPost:
+		var req NewServiceAccount
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		sa := &ServiceAccount{Id: "sa-" + req.Name, Name: req.Name, Description: req.Description, CreatedAt: "2026-01-01T00:00:00Z"}
+		f.serviceAccounts[sa.Id] = sa
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(sa)
+	case parts[0] == "service-accounts" && len(parts) >= 2:
+		sa, ok := f.serviceAccounts[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case len(parts) == 2 && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(sa)
+		case len(parts) == 2 && r.Method == http.MethodPut:
+			var req NewServiceAccount
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sa.Name, sa.Description = req.Name, req.Description
+			_ = json.NewEncoder(w).Encode(sa)
+		case len(parts) == 2 && r.Method == http.MethodDelete:
+			delete(f.serviceAccounts, sa.Id)
+			w.WriteHeader(http.StatusNoContent)
+		case len(parts) == 3 && r.Method == http.MethodPost:
+			var req ServiceAccountRoleRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sa.Roles = append(sa.Roles, UserRoleResponse{Id: req.RoleId})
+			w.WriteHeader(http.StatusCreated)
+		case len(parts) == 4 && r.Method == http.MethodDelete:
+			sa.Roles = lo.Reject(sa.Roles, func(role UserRoleResponse, _ int) bool { return role.Id == parts[3] })
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	case parts[0] == "api-keys" && len(parts) == 1 && r.Method == http.MethodPost:
+		var req NewApiKey
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := f.serviceAccounts[req.ServiceAccountId]; !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		key := &ApiKey{Id: "key-" + req.Name, Name: req.Name, ServiceAccountId: req.ServiceAccountId, KeyPrefix: "kmdr_abc", CreatedAt: "2026-01-01T00:00:00Z"}
+		f.apiKeys[key.Id] = key
+		created := *key
+		created.Key = "kmdr_abc-secret"
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(created)
+	case parts[0] == "api-keys" && len(parts) == 2:
+		key, ok := f.apiKeys[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.apiKeys, key.Id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(key)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestServiceAccountLifecycle(t *testing.T) {
+	api, client := newFakeServiceAccountsServer(t)
+	ctx := context.Background()
+	r := resourceKomodorServiceAccount()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "ci",
+		"description": "CI pipelines",
+		"roles":       []interface{}{"role-viewer", "role-deployer"},
+	})
+	require.False(t, r.CreateContext(ctx, d, client).HasError())
+	assert.Equal(t, "sa-ci", d.Id())
+	assert.ElementsMatch(t, []interface{}{"role-viewer", "role-deployer"}, d.Get("roles").(*schema.Set).List())
+	assert.Equal(t, "2026-01-01T00:00:00Z", d.Get("created_at"))
+
+	require.NoError(t, client.updateServiceAccountRoles("sa-ci", []*string{lo.ToPtr("role-admin")}, []*string{lo.ToPtr("role-deployer")}))
+	assert.ElementsMatch(t, []string{"role-viewer", "role-admin"},
+		lo.Map(api.serviceAccounts["sa-ci"].Roles, func(r UserRoleResponse, _ int) string { return r.Id }))
+
+	require.False(t, r.DeleteContext(ctx, d, client).HasError())
+	assert.Empty(t, api.serviceAccounts)
+
+	d.SetId("sa-ci")
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	assert.Equal(t, "", d.Id(), "a deleted service account is removed from state")
+}
+
+func TestApiKeyKeepsSecretAcrossReads(t *testing.T) {
+	_, client := newFakeServiceAccountsServer(t)
+	ctx := context.Background()
+	_, err := client.CreateServiceAccount(&NewServiceAccount{Name: "ci"})
+	require.NoError(t, err)
+
+	r := resourceKomodorApiKey()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"service_account_id": "sa-ci",
+		"name":               "deploy",
+		"rotation_days":      30,
+	})
+	require.False(t, r.CreateContext(ctx, d, client).HasError())
+	assert.Equal(t, "key-deploy", d.Id())
+	assert.Equal(t, "kmdr_abc-secret", d.Get("key"))
+	assert.Equal(t, "kmdr_abc", d.Get("key_prefix"))
+
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	assert.Equal(t, "kmdr_abc-secret", d.Get("key"), "the key is never returned again, so Read must not clear it")
+
+	require.False(t, r.DeleteContext(ctx, d, client).HasError())
+	_, statusCode, err := client.GetApiKey("key-deploy")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestApiKeyRotationDue(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		createdAt    string
+		rotationDays int
+		want         bool
+	}{
+		{name: "no rotation", createdAt: "2025-01-01T00:00:00Z", rotationDays: 0, want: false},
+		{name: "not yet due", createdAt: "2026-02-15T00:00:00Z", rotationDays: 30, want: false},
+		{name: "due exactly", createdAt: "2026-01-30T00:00:00Z", rotationDays: 30, want: true},
+		{name: "overdue", createdAt: "2025-12-01T00:00:00Z", rotationDays: 30, want: true},
+		{name: "unknown creation time", createdAt: "", rotationDays: 30, want: false},
+		{name: "unparseable creation time", createdAt: "last week", rotationDays: 30, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, apiKeyRotationDue(tt.createdAt, tt.rotationDays, now))
+		})
+	}
+}
+
+func TestApiKeyCustomizeDiffRotates(t *testing.T) {
+	r := resourceKomodorApiKey()
+	state := &terraform.InstanceState{
+		ID: "key-deploy",
+		Attributes: map[string]string{
+			"id":                 "key-deploy",
+			"service_account_id": "sa-ci",
+			"name":               "deploy",
+			"rotation_days":      "30",
+			"created_at":         "2020-01-01T00:00:00Z",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"service_account_id": "sa-ci",
+		"name":               "deploy",
+		"rotation_days":      30,
+	})
+
+	diff, err := r.SimpleDiff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew(), "a key older than rotation_days is replaced")
+
+	state.Attributes["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	diff, err = r.SimpleDiff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || !diff.RequiresNew(), "a fresh key is kept")
+}
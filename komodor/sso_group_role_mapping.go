@@ -0,0 +1,130 @@
+package komodor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// SsoGroupRole is a role granted to the members of an SSO group.
+type SsoGroupRole struct {
+	Id         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// SsoGroup is an identity provider group and the roles mapped to it.
+type SsoGroup struct {
+	GroupName string         `json:"groupName"`
+	Roles     []SsoGroupRole `json:"roles"`
+}
+
+type SsoGroupRoleCreateRequest struct {
+	GroupName  string `json:"groupName"`
+	RoleId     string `json:"roleId"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// SsoGroupRoleUpdateRequest always carries the expiration, so that a nil
+// Expiration clears the one on the server instead of leaving it in place.
+type SsoGroupRoleUpdateRequest struct {
+	GroupName  string  `json:"groupName"`
+	RoleId     string  `json:"roleId"`
+	Expiration *string `json:"expiration"`
+}
+
+type SsoGroupRoleDeleteRequest struct {
+	GroupName string `json:"groupName"`
+	RoleId    string `json:"roleId"`
+}
+
+// GetSsoGroups retrieves every SSO group that has roles mapped to it
+func (c *Client) GetSsoGroups() ([]SsoGroup, error) {
+	var groups []SsoGroup
+
+	res, _, err := c.executeHttpRequest(http.MethodGet, c.GetSsoGroupsUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(res, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetSsoGroup retrieves the roles mapped to an SSO group by its name
+func (c *Client) GetSsoGroup(groupName string) (*SsoGroup, int, error) {
+	var group SsoGroup
+
+	res, statusCode, err := c.executeHttpRequest(http.MethodGet, fmt.Sprintf("%s/%s", c.GetSsoGroupsUrl(), url.PathEscape(groupName)), nil)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	err = json.Unmarshal(res, &group)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return &group, statusCode, nil
+}
+
+// AttachSsoGroupToRole maps an SSO group to a role. An empty expiration grants
+// the role indefinitely; otherwise it must be an RFC3339 timestamp.
+func (c *Client) AttachSsoGroupToRole(groupName string, roleId string, expiration string) error {
+	requestBody, err := json.Marshal(SsoGroupRoleCreateRequest{
+		GroupName:  groupName,
+		RoleId:     roleId,
+		Expiration: expiration,
+	})
+	if err != nil {
+		return err
+	}
+	_, _, err = c.executeHttpRequest(http.MethodPost, c.GetSsoGroupRoleMappingUrl(), &requestBody)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSsoGroupRole updates a group role mapping, e.g. to extend or clear its
+// expiration. An empty expiration clears it, granting the role indefinitely.
+func (c *Client) UpdateSsoGroupRole(groupName string, roleId string, expiration string) error {
+	groupRoleObject := SsoGroupRoleUpdateRequest{
+		GroupName: groupName,
+		RoleId:    roleId,
+	}
+	if expiration != "" {
+		groupRoleObject.Expiration = &expiration
+	}
+	requestBody, err := json.Marshal(groupRoleObject)
+	if err != nil {
+		return err
+	}
+	_, _, err = c.executeHttpRequest(http.MethodPut, c.GetSsoGroupRoleMappingUrl(), &requestBody)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DetachSsoGroupFromRole removes the mapping between an SSO group and a role
+func (c *Client) DetachSsoGroupFromRole(groupName string, roleId string) error {
+	requestBody, err := json.Marshal(SsoGroupRoleDeleteRequest{
+		GroupName: groupName,
+		RoleId:    roleId,
+	})
+	if err != nil {
+		return err
+	}
+	_, _, err = c.executeHttpRequest(http.MethodDelete, c.GetSsoGroupRoleMappingUrl(), &requestBody)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
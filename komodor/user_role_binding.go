@@ -7,13 +7,23 @@ import (
 )
 
 type UserRole struct {
-	UserId string `json:"userId"`
-	RoleId string `json:"roleId"`
+	UserId     string `json:"userId"`
+	RoleId     string `json:"roleId"`
+	Expiration string `json:"expiration,omitempty"`
 }
 
 type UserRoleCreateRequest struct {
-	UserId string `json:"userId"`
-	RoleId string `json:"roleId"`
+	UserId     string `json:"userId"`
+	RoleId     string `json:"roleId"`
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// UserRoleUpdateRequest always carries the expiration, so that a nil
+// Expiration clears the one on the server instead of leaving it in place.
+type UserRoleUpdateRequest struct {
+	UserId     string  `json:"userId"`
+	RoleId     string  `json:"roleId"`
+	Expiration *string `json:"expiration"`
 }
 
 type UserRoleDeleteRequest struct {
@@ -21,11 +31,13 @@ type UserRoleDeleteRequest struct {
 	RoleId string `json:"roleId"`
 }
 
-// AttachUserToRole attaches a user to a role
-func (c *Client) AttachUserToRole(userId string, roleId string) error {
+// AttachUserToRole attaches a user to a role. An empty expiration grants the
+// role indefinitely; otherwise it must be an RFC3339 timestamp.
+func (c *Client) AttachUserToRole(userId string, roleId string, expiration string) error {
 	userRoleObject := UserRoleCreateRequest{
-		UserId: userId,
-		RoleId: roleId,
+		UserId:     userId,
+		RoleId:     roleId,
+		Expiration: expiration,
 	}
 	requestBody, err := json.Marshal(userRoleObject)
 	if err != nil {
@@ -56,8 +68,9 @@ func (c *Client) GetUserRoles(userId string) ([]UserRole, int, error) {
 	userRoles := make([]UserRole, 0, len(user.Roles))
 	for _, role := range user.Roles {
 		userRoles = append(userRoles, UserRole{
-			UserId: userId,
-			RoleId: role.Id,
+			UserId:     userId,
+			RoleId:     role.Id,
+			Expiration: role.Expiration,
 		})
 	}
 
@@ -66,28 +79,39 @@ func (c *Client) GetUserRoles(userId string) ([]UserRole, int, error) {
 
 // DetachUserFromRole detaches a user from a role
 func (c *Client) DetachUserFromRole(userId string, roleId string) error {
+	_, err := c.detachUserFromRole(userId, roleId)
+	return err
+}
+
+// detachUserFromRole is DetachUserFromRole, also returning the status code
+// so that callers can tell a user that no longer exists apart.
+func (c *Client) detachUserFromRole(userId string, roleId string) (int, error) {
 	userRoleObject := UserRoleDeleteRequest{
 		UserId: userId,
 		RoleId: roleId,
 	}
 	requestBody, err := json.Marshal(userRoleObject)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	_, _, err = c.executeHttpRequest(http.MethodDelete, c.GetUserRoleBindingUrl(), &requestBody)
+	_, statusCode, err := c.executeHttpRequest(http.MethodDelete, c.GetUserRoleBindingUrl(), &requestBody)
 	if err != nil {
-		return err
+		return statusCode, err
 	}
 
-	return nil
+	return statusCode, nil
 }
 
-// UpdateUserRole updates a user role assignment
-func (c *Client) UpdateUserRole(userId string, roleId string) error {
-	userRoleObject := UserRoleCreateRequest{
+// UpdateUserRole updates a user role assignment, e.g. to extend or clear its
+// expiration. An empty expiration clears it, granting the role indefinitely.
+func (c *Client) UpdateUserRole(userId string, roleId string, expiration string) error {
+	userRoleObject := UserRoleUpdateRequest{
 		UserId: userId,
 		RoleId: roleId,
 	}
+	if expiration != "" {
+		userRoleObject.Expiration = &expiration
+	}
 	requestBody, err := json.Marshal(userRoleObject)
 	if err != nil {
 		return err
@@ -31,6 +31,21 @@ type UpdateUser struct {
 	DisplayName string `json:"displayName"`
 }
 
+func (c *Client) GetUsers() ([]User, error) {
+	res, _, err := c.executeHttpRequest(http.MethodGet, c.GetUsersUrl(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	err = json.Unmarshal(res, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (c *Client) GetUser(idOrEmail string) (*User, int, error) {
 	var user User
 	res, statusCode, err := c.executeHttpRequest(http.MethodGet, fmt.Sprintf("%s/%s", c.GetUsersUrl(), idOrEmail), nil)
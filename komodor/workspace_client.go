@@ -1,25 +1,104 @@
 package komodor
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Workspace kinds. A workspace without a kind is a scopes workspace.
+const (
+	WorkspaceKindScopes        = "scopes"
+	WorkspaceKindClusterGroup  = "cluster_group"
+	WorkspaceKindLabelSelector = "label_selector"
 )
 
 type Workspace struct {
-	Id                 string           `json:"id"`
-	Name               string           `json:"name"`
-	Description        string           `json:"description"`
-	Scopes             []ResourcesScope `json:"scopes"`
-	AuthorEmail        string           `json:"AuthorEmail"`
-	LastUpdatedByEmail string           `json:"LastUpdatedByEmail"`
-	CreatedAt          string           `json:"createdAt"`
-	LastUpdated        string           `json:"lastUpdated"`
+	Id                 string                  `json:"id"`
+	Name               string                  `json:"name"`
+	Description        string                  `json:"description"`
+	Kind               string                  `json:"kind,omitempty"`
+	Scopes             []ResourcesScope        `json:"scopes"`
+	ClusterGroup       *WorkspaceClusterGroup  `json:"clusterGroup,omitempty"`
+	LabelSelector      *WorkspaceLabelSelector `json:"labelSelector,omitempty"`
+	AuthorEmail        string                  `json:"AuthorEmail"`
+	LastUpdatedByEmail string                  `json:"LastUpdatedByEmail"`
+	CreatedAt          string                  `json:"createdAt"`
+	LastUpdated        string                  `json:"lastUpdated"`
 }
 
 type NewWorkspace struct {
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	Scopes      []ResourcesScope `json:"scopes"`
+	Name          string                  `json:"name"`
+	Description   string                  `json:"description"`
+	Kind          string                  `json:"kind,omitempty"`
+	Scopes        []ResourcesScope        `json:"scopes"`
+	ClusterGroup  *WorkspaceClusterGroup  `json:"clusterGroup,omitempty"`
+	LabelSelector *WorkspaceLabelSelector `json:"labelSelector,omitempty"`
+}
+
+// WorkspaceClusterGroup defines a cluster_group workspace: every resource in
+// the named clusters and in the clusters matching the patterns.
+type WorkspaceClusterGroup struct {
+	Clusters         []string  `json:"clusters"`
+	ClustersPatterns []Pattern `json:"clustersPatterns"`
+}
+
+// WorkspaceLabelSelector defines a label_selector workspace: every resource in
+// the clusters whose labels include all of MatchLabels.
+type WorkspaceLabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+// EffectiveKind returns the kind of the workspace, defaulting to scopes for
+// workspaces created before kinds existed.
+func (w *Workspace) EffectiveKind() string {
+	if w.Kind == "" {
+		return WorkspaceKindScopes
+	}
+	return w.Kind
+}
+
+// executeWorkspaceRequest executes a request against the workspaces API,
+// which may answer with any 2xx status, including ones executeHttpRequest
+// treats as errors.
+func (c *Client) executeWorkspaceRequest(method string, url string, body *[]byte) ([]byte, int, error) {
+	resBody, statusCode, err := c.executeHttpRequest(method, url, body)
+	if err != nil && statusCode >= 200 && statusCode < 300 {
+		return resBody, statusCode, nil
+	}
+	return resBody, statusCode, err
+}
+
+// createWorkspaceRequest posts a new workspace, once: a retried create could
+// add a second workspace with the same name. Besides the body it returns the
+// Location header, which carries the ID when the body is empty.
+func (c *Client) createWorkspaceRequest(body *[]byte) ([]byte, string, error) {
+	req, err := c.prepareRequest(http.MethodPost, c.GetWorkspacesUrl(), body)
+	if err != nil {
+		return nil, "", err
+	}
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return resBody, "", fmt.Errorf("received error response: %d %s", res.StatusCode, resBody)
+	}
+
+	return resBody, res.Header.Get("Location"), nil
 }
 
 func (c *Client) CreateWorkspace(workspace *NewWorkspace) (*Workspace, error) {
@@ -28,31 +107,80 @@ func (c *Client) CreateWorkspace(workspace *NewWorkspace) (*Workspace, error) {
 		return nil, fmt.Errorf("failed to marshal workspace: %w", err)
 	}
 
-	resBody, statusCode, err := c.executeHttpRequest("POST", c.GetWorkspacesUrl(), &body)
+	resBody, location, err := c.createWorkspaceRequest(&body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workspace: %w", err)
 	}
 
-	if statusCode != 201 {
-		return nil, fmt.Errorf("unexpected status code: %d", statusCode)
+	if len(bytes.TrimSpace(resBody)) > 0 {
+		var response Workspace
+		if err := json.Unmarshal(resBody, &response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		if response.Id != "" {
+			return &response, nil
+		}
 	}
 
-	var response Workspace
+	if location != "" {
+		id := path.Base(strings.TrimSuffix(location, "/"))
+		created, _, err := c.GetWorkspace(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read created workspace %s: %w", id, err)
+		}
+		return created, nil
+	}
+
+	// Names are not unique, so the workspace can't be safely found by name.
+	// Name the candidates instead, for the user to import the right one.
+	candidates := "none were found"
+	if workspaces, err := c.GetWorkspaces(); err == nil {
+		named := lo.Filter(workspaces, func(w Workspace, _ int) bool { return w.Name == workspace.Name })
+		if len(named) > 0 {
+			candidates = "workspaces with that name: " + strings.Join(lo.Map(named, func(w Workspace, _ int) string { return w.Id }), ", ")
+		}
+	}
+	return nil, fmt.Errorf("workspace %q may have been created, but Komodor did not return its ID (%s); import it with terraform import if it exists", workspace.Name, candidates)
+}
+
+func (c *Client) GetWorkspaces() ([]Workspace, error) {
+	resBody, _, err := c.executeWorkspaceRequest("GET", c.GetWorkspacesUrl(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var response []Workspace
 	if err := json.Unmarshal(resBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	return &response, nil
+	return response, nil
 }
 
-func (c *Client) GetWorkspace(id string) (*Workspace, int, error) {
-	resBody, statusCode, err := c.executeHttpRequest("GET", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), nil)
+// GetWorkspaceByName returns the workspace with the given name. Workspace
+// names are not unique, so finding more than one is an error.
+func (c *Client) GetWorkspaceByName(name string) (*Workspace, error) {
+	workspaces, err := c.GetWorkspaces()
 	if err != nil {
-		return nil, statusCode, fmt.Errorf("failed to get workspace: %w", err)
+		return nil, err
 	}
 
-	if statusCode == 404 {
-		return nil, statusCode, nil
+	matching := lo.Filter(workspaces, func(w Workspace, _ int) bool { return w.Name == name })
+	switch len(matching) {
+	case 0:
+		return nil, fmt.Errorf("no workspace named %q", name)
+	case 1:
+		return &matching[0], nil
+	default:
+		ids := lo.Map(matching, func(w Workspace, _ int) string { return w.Id })
+		return nil, fmt.Errorf("%d workspaces are named %q (%s); look the workspace up by id instead", len(matching), name, strings.Join(ids, ", "))
+	}
+}
+
+func (c *Client) GetWorkspace(id string) (*Workspace, int, error) {
+	resBody, statusCode, err := c.executeWorkspaceRequest("GET", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), nil)
+	if err != nil {
+		return nil, statusCode, fmt.Errorf("failed to get workspace: %w", err)
 	}
 
 	var response Workspace
@@ -69,13 +197,17 @@ func (c *Client) UpdateWorkspace(id string, workspace *NewWorkspace) (*Workspace
 		return nil, fmt.Errorf("failed to marshal workspace: %w", err)
 	}
 
-	resBody, statusCode, err := c.executeHttpRequest("PUT", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), &body)
+	resBody, _, err := c.executeWorkspaceRequest("PUT", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), &body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update workspace: %w", err)
 	}
 
-	if statusCode != 200 {
-		return nil, fmt.Errorf("unexpected status code: %d", statusCode)
+	if len(bytes.TrimSpace(resBody)) == 0 {
+		updated, _, err := c.GetWorkspace(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read updated workspace: %w", err)
+		}
+		return updated, nil
 	}
 
 	var response Workspace
@@ -86,14 +218,78 @@ func (c *Client) UpdateWorkspace(id string, workspace *NewWorkspace) (*Workspace
 	return &response, nil
 }
 
+// DeleteWorkspace deletes a workspace. Deleting a workspace that no longer
+// exists is not an error.
 func (c *Client) DeleteWorkspace(id string) error {
-	_, statusCode, err := c.executeHttpRequest("DELETE", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), nil)
+	_, statusCode, err := c.executeWorkspaceRequest("DELETE", fmt.Sprintf("%s/%s", c.GetWorkspacesUrl(), id), nil)
 	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Workspace (%s) was already deleted", id)
+			return nil
+		}
 		return fmt.Errorf("failed to delete workspace: %w", err)
 	}
 
-	if statusCode != 204 {
-		return fmt.Errorf("unexpected status code: %d", statusCode)
+	return nil
+}
+
+// WorkspaceAccess lists the users and roles a workspace is shared with.
+type WorkspaceAccess struct {
+	Users []string              `json:"users"`
+	Roles []WorkspaceRoleAccess `json:"roles"`
+}
+
+// WorkspaceRoleAccess shares a workspace with a role. IsDefault makes it the
+// workspace the role's members land in.
+type WorkspaceRoleAccess struct {
+	RoleId    string `json:"roleId"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+func (c *Client) GetWorkspaceAccess(id string) (*WorkspaceAccess, int, error) {
+	resBody, statusCode, err := c.executeWorkspaceRequest("GET", fmt.Sprintf("%s/%s/access", c.GetWorkspacesUrl(), id), nil)
+	if err != nil {
+		return nil, statusCode, fmt.Errorf("failed to get workspace access: %w", err)
+	}
+
+	var response WorkspaceAccess
+	if err := json.Unmarshal(resBody, &response); err != nil {
+		return nil, statusCode, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &response, statusCode, nil
+}
+
+// SetWorkspaceAccess replaces the users and roles a workspace is shared with.
+func (c *Client) SetWorkspaceAccess(id string, access *WorkspaceAccess) error {
+	body, err := json.Marshal(access)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace access: %w", err)
+	}
+
+	_, _, err = c.executeWorkspaceRequest("PUT", fmt.Sprintf("%s/%s/access", c.GetWorkspacesUrl(), id), &body)
+	if err != nil {
+		return fmt.Errorf("failed to set workspace access: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeWorkspaceAccess stops sharing a workspace with anyone. A deleted
+// workspace takes its access with it, so a missing workspace is not an error.
+func (c *Client) RevokeWorkspaceAccess(id string) error {
+	body, err := json.Marshal(&WorkspaceAccess{Users: []string{}, Roles: []WorkspaceRoleAccess{}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace access: %w", err)
+	}
+
+	_, statusCode, err := c.executeWorkspaceRequest("PUT", fmt.Sprintf("%s/%s/access", c.GetWorkspacesUrl(), id), &body)
+	if err != nil {
+		if statusCode == 404 {
+			log.Printf("[DEBUG] Workspace (%s) was not found - nothing to revoke", id)
+			return nil
+		}
+		return fmt.Errorf("failed to revoke workspace access: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,126 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWorkspaceTestServer serves a single workspace, ws-1, answering writes
+// with the given status code, and without a body when it is 204. Creates
+// point at ws-1 through the Location header. Any other workspace is not found.
+func newWorkspaceTestServer(t *testing.T, writeStatus int) *httptest.Server {
+	t.Helper()
+	workspace := Workspace{Id: "ws-1", Name: "team-a", Scopes: []ResourcesScope{{Clusters: []string{"prod"}}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/workspaces":
+			w.Header().Set("Location", "/api/v2/workspaces/ws-1")
+			w.WriteHeader(writeStatus)
+			_ = json.NewEncoder(w).Encode(workspace)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/workspaces":
+			_ = json.NewEncoder(w).Encode([]Workspace{workspace})
+		case r.URL.Path != "/api/v2/workspaces/ws-1":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(workspace)
+		case r.Method == http.MethodPut:
+			w.WriteHeader(writeStatus)
+			_ = json.NewEncoder(w).Encode(workspace)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(writeStatus)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWorkspaceClientAcceptsAnySuccessStatus(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := NewClient("key", newWorkspaceTestServer(t, status).URL)
+
+			created, err := client.CreateWorkspace(&NewWorkspace{Name: "team-a"})
+			require.NoError(t, err)
+			assert.Equal(t, "ws-1", created.Id)
+
+			updated, err := client.UpdateWorkspace("ws-1", &NewWorkspace{Name: "team-a"})
+			require.NoError(t, err)
+			assert.Equal(t, "team-a", updated.Name)
+			assert.Equal(t, []string{"prod"}, updated.Scopes[0].Clusters)
+
+			require.NoError(t, client.DeleteWorkspace("ws-1"))
+		})
+	}
+}
+
+func TestCreateWorkspaceWithoutIdIsNotLookedUpByName(t *testing.T) {
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode([]Workspace{{Id: "ws-1", Name: "team-a"}, {Id: "ws-2", Name: "team-a"}})
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("key", server.URL)
+
+	created, err := client.CreateWorkspace(&NewWorkspace{Name: "team-a"})
+
+	assert.Nil(t, created)
+	assert.ErrorContains(t, err, `workspace "team-a" may have been created`)
+	assert.ErrorContains(t, err, "ws-1, ws-2")
+	assert.ErrorContains(t, err, "terraform import")
+	assert.Equal(t, 1, posts)
+}
+
+func TestWorkspaceClientNotFound(t *testing.T) {
+	client := NewClient("key", newWorkspaceTestServer(t, http.StatusOK).URL)
+
+	workspace, statusCode, err := client.GetWorkspace("ws-gone")
+	require.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Nil(t, workspace)
+
+	assert.NoError(t, client.DeleteWorkspace("ws-gone"), "deleting a deleted workspace is not an error")
+
+	_, err = client.UpdateWorkspace("ws-gone", &NewWorkspace{Name: "team-a"})
+	assert.Error(t, err)
+}
+
+func TestWorkspaceClientErrorStatus(t *testing.T) {
+	client := NewClient("key", newWorkspaceTestServer(t, http.StatusForbidden).URL)
+
+	_, err := client.CreateWorkspace(&NewWorkspace{Name: "team-a"})
+	assert.ErrorContains(t, err, "403")
+	assert.ErrorContains(t, client.DeleteWorkspace("ws-1"), "403")
+}
+
+func TestWorkspaceResourceNotFound(t *testing.T) {
+	client := NewClient("key", newWorkspaceTestServer(t, http.StatusOK).URL)
+	ctx := context.Background()
+	r := resourceKomodorWorkspace()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, testWorkspaceConfig())
+	d.SetId("ws-1")
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	assert.Equal(t, "ws-1", d.Id())
+	assert.Equal(t, "team-a", d.Get("name"))
+
+	d.SetId("ws-gone")
+	require.False(t, r.ReadContext(ctx, d, client).HasError())
+	assert.Equal(t, "", d.Id(), "a deleted workspace is removed from state")
+
+	d.SetId("ws-gone")
+	require.False(t, r.DeleteContext(ctx, d, client).HasError())
+	assert.Equal(t, "", d.Id())
+}
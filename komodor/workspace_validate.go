@@ -0,0 +1,171 @@
+package komodor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/samber/lo"
+)
+
+// isEmptyResourcesScope reports whether a scope sets none of its fields.
+// Depending on the backend, such a scope either matches nothing or everything.
+func isEmptyResourcesScope(rs ResourcesScope) bool {
+	s := rs.toScope()
+	return s.Clusters.IsEmpty() && s.Namespaces.IsEmpty() && len(s.Selectors) == 0 && len(s.SelectorPatterns) == 0
+}
+
+// analyzeWorkspaceScopes reports workspace scopes that are empty or whose
+// patterns fail to compile or match nothing.
+func analyzeWorkspaceScopes(scopes []ResourcesScope) []policyFinding {
+	var findings []policyFinding
+	for i, rs := range scopes {
+		path := cty.GetAttrPath("scopes").IndexInt(i)
+		if isEmptyResourcesScope(rs) {
+			findings = append(findings, policyFinding{
+				Path:    path,
+				Summary: "Empty scope",
+				Detail:  fmt.Sprintf("scopes[%d] sets none of clusters, namespaces, clusters_patterns, namespaces_patterns, selectors or selectors_patterns.", i),
+			})
+			continue
+		}
+		findings = append(findings, analyzeScopePatterns(path, &rs)...)
+	}
+	return findings
+}
+
+// analyzeWorkspace checks that a workspace sets exactly the block its kind
+// calls for, and analyzes that block.
+func analyzeWorkspace(kind string, ws *NewWorkspace) []policyFinding {
+	var findings []policyFinding
+	missing := func(block string) {
+		findings = append(findings, policyFinding{
+			Path:    cty.GetAttrPath(block),
+			Summary: "Missing " + block,
+			Detail:  fmt.Sprintf("a workspace of kind %q must set %s.", kind, block),
+		})
+	}
+	notAllowed := func(block string) {
+		findings = append(findings, policyFinding{
+			Path:    cty.GetAttrPath(block),
+			Summary: "Unexpected " + block,
+			Detail:  fmt.Sprintf("%s cannot be set on a workspace of kind %q.", block, kind),
+		})
+	}
+
+	if kind != WorkspaceKindScopes && len(ws.Scopes) > 0 {
+		notAllowed("scopes")
+	}
+	if kind != WorkspaceKindClusterGroup && ws.ClusterGroup != nil {
+		notAllowed("cluster_group")
+	}
+	if kind != WorkspaceKindLabelSelector && ws.LabelSelector != nil {
+		notAllowed("label_selector")
+	}
+
+	switch kind {
+	case WorkspaceKindScopes:
+		if len(ws.Scopes) == 0 {
+			missing("scopes")
+		}
+		findings = append(findings, analyzeWorkspaceScopes(ws.Scopes)...)
+	case WorkspaceKindClusterGroup:
+		group := ws.ClusterGroup
+		path := cty.GetAttrPath("cluster_group").IndexInt(0)
+		switch {
+		case group == nil:
+			missing("cluster_group")
+		case len(group.Clusters) == 0 && len(group.ClustersPatterns) == 0:
+			findings = append(findings, policyFinding{
+				Path:    path,
+				Summary: "Empty cluster_group",
+				Detail:  "cluster_group sets neither clusters nor clusters_patterns.",
+			})
+		default:
+			findings = append(findings, analyzeScopePatterns(path, &ResourcesScope{ClustersPatterns: group.ClustersPatterns})...)
+		}
+	case WorkspaceKindLabelSelector:
+		switch {
+		case ws.LabelSelector == nil:
+			missing("label_selector")
+		case len(ws.LabelSelector.MatchLabels) == 0:
+			findings = append(findings, policyFinding{
+				Path:    cty.GetAttrPath("label_selector").IndexInt(0).GetAttr("match_labels"),
+				Summary: "Empty label_selector",
+				Detail:  "match_labels must contain at least one label.",
+			})
+		}
+	}
+	return findings
+}
+
+func resourceKomodorWorkspaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"kind", "scopes", "cluster_group", "label_selector"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	kind := d.Get("kind").(string)
+	ws := expandWorkspaceFields("", "", kind,
+		d.Get("scopes").([]interface{}),
+		d.Get("cluster_group").([]interface{}),
+		d.Get("label_selector").([]interface{}))
+	findings := analyzeWorkspace(kind, ws)
+	if len(findings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("found %d problem(s) in the workspace definition:\n  - %s",
+		len(findings), strings.Join(lo.Map(findings, func(f policyFinding, _ int) string { return formatPolicyFinding(f) }), "\n  - "))
+}
+
+// workspaceClusterWarnings warns about clusters a workspace names that are
+// not onboarded to Komodor. Failing to check a cluster is not an error.
+// It runs after create and update rather than at plan time: CustomizeDiff
+// can only fail a plan, and raw config validation has no client.
+func (c *Client) workspaceClusterWarnings(ws *NewWorkspace) diag.Diagnostics {
+	type clusterRef struct {
+		name string
+		path cty.Path
+	}
+	var refs []clusterRef
+	for i, rs := range ws.Scopes {
+		for j, cluster := range rs.Clusters {
+			refs = append(refs, clusterRef{cluster, cty.GetAttrPath("scopes").IndexInt(i).GetAttr("clusters").IndexInt(j)})
+		}
+	}
+	if ws.ClusterGroup != nil {
+		for j, cluster := range ws.ClusterGroup.Clusters {
+			refs = append(refs, clusterRef{cluster, cty.GetAttrPath("cluster_group").IndexInt(0).GetAttr("clusters").IndexInt(j)})
+		}
+	}
+
+	var diags diag.Diagnostics
+	checked := make(map[string]bool)
+	for _, ref := range refs {
+		if checked[ref.name] {
+			continue
+		}
+		checked[ref.name] = true
+
+		_, statusCode, err := c.GetKubernetesCluster(ref.name)
+		if err == nil {
+			continue
+		}
+		if statusCode != 404 {
+			log.Printf("[WARN] Could not check whether cluster %s is onboarded: %s", ref.name, err)
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Cluster not onboarded",
+			Detail:        fmt.Sprintf("Cluster %q in %s is not connected to Komodor, so the workspace shows nothing from it until it is.", ref.name, formatCtyPath(ref.path)),
+			AttributePath: ref.path,
+		})
+	}
+	return diags
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/komodorio/terraform-provider-komodor/komodor"
 )
 
@@ -9,6 +12,12 @@ import (
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: komodor.Provider})
+	providerServer, err := komodor.NewProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/komodorio/komodor", providerServer); err != nil {
+		log.Fatal(err)
+	}
 }